@@ -1,11 +1,23 @@
+// Issues are scoped to a project; the default project holds everything that
+// existed before projects were introduced.
+const projectId =
+  process.env.NEXT_PUBLIC_PROJECT_ID ?? "00000000-0000-0000-0000-000000000001";
+const issuesUrl = `http://localhost:8000/projects/${projectId}/issues`;
+
+const authHeaders = (): Record<string, string> =>
+  process.env.NEXT_PUBLIC_API_TOKEN
+    ? { Authorization: `Bearer ${process.env.NEXT_PUBLIC_API_TOKEN}` }
+    : {};
+
 export const createIssue = async (issue: {
   title: string;
   description: string;
 }) => {
-  const response = await fetch("http://localhost:8000/issues/new", {
+  const response = await fetch(`${issuesUrl}/new`, {
     method: "POST",
     headers: {
       "Content-Type": "application/json",
+      ...authHeaders(),
     },
     body: JSON.stringify({ issue }),
   });
@@ -13,32 +25,44 @@ export const createIssue = async (issue: {
 };
 
 export const listIssues = async () => {
-  const response = await fetch("http://localhost:8000/issues");
+  const response = await fetch(issuesUrl, {
+    headers: authHeaders(),
+  });
   return response.json();
 };
 
 export const getIssue = async (id: string) => {
-  const response = await fetch(`http://localhost:8000/issues/${id}`);
+  const response = await fetch(`${issuesUrl}/${id}`, {
+    headers: authHeaders(),
+  });
   return response.json();
 };
 
+// Writes must name the version of the issue they were based on, so that a
+// stale edit is rejected instead of overwriting someone else's change.
+const ifMatch = (version: number) => ({ "If-Match": `"${version}"` });
+
 export const updateIssue = async (
   id: string,
-  issue: { title: string; description: string }
+  issue: { title: string; description: string },
+  version: number
 ) => {
-  const response = await fetch(`http://localhost:8000/issues/${id}`, {
-    method: "PUT",
+  const response = await fetch(`${issuesUrl}/${id}`, {
+    method: "PATCH",
     headers: {
-      "Content-Type": "application/json",
+      "Content-Type": "application/merge-patch+json",
+      ...ifMatch(version),
+      ...authHeaders(),
     },
-    body: JSON.stringify({ issue }),
+    body: JSON.stringify(issue),
   });
   return response.json();
 };
 
-export const deleteIssue = async (id: string) => {
-  const response = await fetch(`http://localhost:8000/issues/${id}`, {
+export const deleteIssue = async (id: string, version: number) => {
+  const response = await fetch(`${issuesUrl}/${id}`, {
     method: "DELETE",
+    headers: { ...ifMatch(version), ...authHeaders() },
   });
   return response.json();
 };
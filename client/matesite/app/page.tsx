@@ -10,6 +10,7 @@ interface Issue {
   id: string;
   title: string;
   description: string;
+  version?: number;
 }
 
 const App: React.FC = () => {
@@ -18,10 +19,11 @@ const App: React.FC = () => {
 
   const handleSave = async (issue: Issue) => {
     if (currentIssue) {
-      await updateIssue(currentIssue.id, {
-        title: issue.title,
-        description: issue.description,
-      });
+      await updateIssue(
+        currentIssue.id,
+        { title: issue.title, description: issue.description },
+        currentIssue.version ?? 0
+      );
     } else {
       await createIssue({ title: issue.title, description: issue.description });
     }
@@ -4,6 +4,7 @@ interface Issue {
   id: string;
   title: string;
   description: string;
+  version?: number;
 }
 
 interface IssueFormProps {
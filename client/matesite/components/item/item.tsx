@@ -4,12 +4,13 @@ interface Issue {
   id: string;
   title: string;
   description: string;
+  version?: number;
 }
 
 interface IssueItemProps {
   issue: Issue;
   onEdit: (issue: Issue) => void;
-  onDelete: (id: string) => void;
+  onDelete: (issue: Issue) => void;
 }
 
 const IssueItem: React.FC<IssueItemProps> = ({ issue, onEdit, onDelete }) => {
@@ -24,7 +25,7 @@ const IssueItem: React.FC<IssueItemProps> = ({ issue, onEdit, onDelete }) => {
         Edit
       </button>
       <button
-        onClick={() => onDelete(issue.id)}
+        onClick={() => onDelete(issue)}
         className='px-4 py-2 bg-red-500 text-white rounded hover:bg-red-600'
       >
         Delete
@@ -7,6 +7,7 @@ interface Issue {
   id: string;
   title: string;
   description: string;
+  version?: number;
 }
 
 interface IssueListProps {
@@ -19,8 +20,8 @@ const IssueList: React.FC<IssueListProps> = ({ onEdit }) => {
   if (isLoading) return <div className='text-gray-800'>Loading...</div>;
   if (isError) return <div className='text-red-500'>Error loading issues</div>;
 
-  const handleDelete = async (id: string) => {
-    await deleteIssue(id);
+  const handleDelete = async (issue: Issue) => {
+    await deleteIssue(issue.id, issue.version ?? 0);
     mutate();
   };
 
@@ -0,0 +1,33 @@
+package auth
+
+import "context"
+
+type principalKey struct{}
+
+// Me is the alias callers can use in filters to refer to the user making the
+// request.
+const Me = "me"
+
+// Principal is the authenticated caller of a request. Its roles are not part
+// of the token; they are looked up from the users table when authorizing.
+type Principal struct {
+	UserID string
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// UserID returns the ID of the user the request is made on behalf of, if any.
+func UserID(ctx context.Context) (string, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return "", false
+	}
+	return principal.UserID, true
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid bearer token")
+)
+
+// Authenticator turns a bearer token into the principal it was issued to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+type jwtAuthenticator struct {
+	secret   []byte
+	keys     jose.JSONWebKeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+// NewJWTAuthenticator verifies HS256 tokens against a shared secret and RS256
+// tokens against a PEM public key and/or a local JWKS file.
+func NewJWTAuthenticator(cfg config.AuthConfig) (Authenticator, error) {
+	a := &jwtAuthenticator{
+		secret:   []byte(cfg.JWTSecret),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		leeway:   cfg.Leeway,
+	}
+
+	if cfg.JWKSFile != "" {
+		raw, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		if err := json.Unmarshal(raw, &a.keys); err != nil {
+			return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+		}
+	}
+
+	if cfg.JWTPublicKeyFile != "" {
+		raw, err := os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return nil, errors.New("failed to decode JWT public key: no PEM block found")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+		}
+		a.keys.Keys = append(a.keys.Keys, jose.JSONWebKey{Key: key, Algorithm: string(jose.RS256), Use: "sig"})
+	}
+
+	if len(a.secret) > 0 && len(a.secret) < config.MinJWTSecretLength {
+		return nil, fmt.Errorf("AUTH_JWT_SECRET must be at least %d bytes long", config.MinJWTSecretLength)
+	}
+	if len(a.secret) == 0 && len(a.keys.Keys) == 0 {
+		return nil, errors.New("no JWT verification key configured, set AUTH_JWT_SECRET, AUTH_JWT_PUBLIC_KEY_FILE or AUTH_JWKS_FILE")
+	}
+
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.HS256, jose.RS256})
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := a.key(parsed.Headers[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var claims jwt.Claims
+	if err := parsed.Claims(key, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Expiry == nil || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	expected := jwt.Expected{Issuer: a.issuer, Time: time.Now()}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, a.leeway); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		UserID: claims.Subject,
+	}, nil
+}
+
+// key picks the verification key for a token header. The algorithm decides
+// between the shared secret and the public keys so an RS256 public key can
+// never be used as an HMAC secret.
+func (a *jwtAuthenticator) key(header jose.Header) (any, error) {
+	switch jose.SignatureAlgorithm(header.Algorithm) {
+	case jose.HS256:
+		if len(a.secret) == 0 {
+			return nil, ErrInvalidToken
+		}
+		return a.secret, nil
+	case jose.RS256:
+		if header.KeyID != "" {
+			if keys := a.keys.Key(header.KeyID); len(keys) > 0 {
+				return keys[0].Key, nil
+			}
+			return nil, ErrInvalidToken
+		}
+		if len(a.keys.Keys) == 1 {
+			return a.keys.Keys[0].Key, nil
+		}
+	}
+	return nil, ErrInvalidToken
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/gofiber/fiber/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const bearerPrefix = "Bearer "
+
+// NewMiddleware rejects requests without a valid bearer token and stores the
+// authenticated principal in the request's user context.
+func NewMiddleware(authenticator Authenticator) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		principal, err := authenticator.Authenticate(ctx.UserContext(), bearerToken(ctx.Get(fiber.HeaderAuthorization)))
+		if err != nil {
+			ctx.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+			return errs.Unauthenticated(err.Error())
+		}
+
+		ctx.SetUserContext(WithPrincipal(ctx.UserContext(), principal))
+		return ctx.Next()
+	}
+}
+
+// UnaryServerInterceptor is the gRPC counterpart of NewMiddleware, reading the
+// token from the "authorization" metadata key. Methods of publicServices, such
+// as the health service, are served without authentication.
+func UnaryServerInterceptor(authenticator Authenticator, publicServices ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for _, service := range publicServices {
+			if strings.HasPrefix(info.FullMethod, "/"+service+"/") {
+				return handler(ctx, req)
+			}
+		}
+
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				token = bearerToken(values[0])
+			}
+		}
+
+		principal, err := authenticator.Authenticate(ctx, token)
+		if err != nil {
+			return nil, errs.Unauthenticated(err.Error())
+		}
+
+		return handler(WithPrincipal(ctx, principal), req)
+	}
+}
+
+func bearerToken(header string) string {
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):])
+}
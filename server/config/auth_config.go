@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+)
+
+// MinJWTSecretLength is the shortest HS256 secret accepted, in bytes: as long
+// as the hash it keys, as RFC 7518 requires.
+const MinJWTSecretLength = 32
+
+type AuthConfig struct {
+	JWTSecret        string        `env:"AUTH_JWT_SECRET" file:"jwt_secret" secret:"true" validate:"omitempty,minbytes=32"`
+	JWTPublicKeyFile string        `env:"AUTH_JWT_PUBLIC_KEY_FILE" file:"jwt_public_key_file"`
+	JWKSFile         string        `env:"AUTH_JWKS_FILE" file:"jwks_file"`
+	Issuer           string        `env:"AUTH_JWT_ISSUER" file:"jwt_issuer"`
+	Audience         string        `env:"AUTH_JWT_AUDIENCE" file:"jwt_audience"`
+	Leeway           time.Duration `env:"AUTH_JWT_LEEWAY_SECONDS" file:"jwt_leeway_seconds" unit:"1s" default:"30" validate:"gte=0"`
+	CORSAllowOrigins []string      `env:"CORS_ALLOW_ORIGINS" file:"cors_allow_origins" reload:"true" validate:"dive,origin"`
+}
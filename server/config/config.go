@@ -0,0 +1,347 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+)
+
+// ConfigFileEnv names the configuration file when no path is given to Load.
+const ConfigFileEnv = "MATESITE_CONFIG"
+
+// Sources a configuration value can come from, from lowest to highest
+// precedence.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceVault   = "vault"
+	SourceDotEnv  = ".env"
+	SourceEnv     = "env"
+)
+
+// Config is the whole configuration of the service. Every value is named by
+// an environment variable, the env tag, and by a key of the configuration
+// file, the file tags of its section and field joined by a dot, such as
+// database.host. Values tagged reload:"true" can be changed without a
+// restart, see Reloader.
+type Config struct {
+	Server    ServerConfig   `file:"server"`
+	Endpoints EndpointConfig `file:"endpoints"`
+	Log       LogConfig      `file:"log"`
+	Auth      AuthConfig     `file:"auth"`
+	Database  DatabaseConfig `file:"database"`
+	Trash     TrashConfig    `file:"trash"`
+	Metrics   MetricsConfig  `file:"metrics"`
+	Tracing   TracingConfig  `file:"tracing"`
+	Health    HealthConfig   `file:"health"`
+	Shutdown  ShutdownConfig `file:"shutdown"`
+
+	values []Value
+}
+
+// Value is a configuration value and where it was loaded from.
+type Value struct {
+	Env    string
+	Key    string
+	Value  string
+	Source string
+	Secret bool
+}
+
+// Values lists every configuration value in declaration order.
+func (c *Config) Values() []Value {
+	return c.values
+}
+
+// source looks configuration values up by environment variable and file key.
+type source struct {
+	name   string
+	lookup func(env string, key string) (string, bool)
+}
+
+// Load reads the configuration once, each value from the source with the
+// highest precedence that sets it:
+//
+//  1. the environment
+//  2. the .env file of the working directory
+//  3. Vault, when VAULT_ADDR is set, see vault.ConfigFromEnv
+//  4. the YAML or TOML file at path, or at $MATESITE_CONFIG
+//  5. the defaults
+//
+// Empty values count as unset. Every malformed or invalid value is reported
+// in the returned error, not just the first. Secret values are registered with
+// the logger so that they are redacted.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+
+	var loadErrs []error
+	fileValues := map[string]string{}
+	if path != "" {
+		var err error
+		if fileValues, err = readFile(path); err != nil {
+			loadErrs = append(loadErrs, err)
+		}
+	}
+	vaultValues, err := readVault()
+	if err != nil {
+		loadErrs = append(loadErrs, err)
+	}
+	dotEnvValues, err := godotenv.Read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		loadErrs = append(loadErrs, fmt.Errorf("failed to read .env: %w", err))
+	}
+
+	sources := []source{
+		{SourceEnv, func(env string, _ string) (string, bool) { return os.LookupEnv(env) }},
+		{SourceDotEnv, func(env string, _ string) (string, bool) { value, ok := dotEnvValues[env]; return value, ok }},
+		{SourceVault, func(env string, _ string) (string, bool) { value, ok := vaultValues[env]; return value, ok }},
+		{SourceFile, func(_ string, key string) (string, bool) { value, ok := fileValues[key]; return value, ok }},
+	}
+
+	config := &Config{}
+	for _, field := range fields(config) {
+		value := Value{Env: field.env, Key: field.key, Secret: field.secret}
+		for _, source := range sources {
+			if raw, ok := source.lookup(field.env, field.key); ok && raw != "" {
+				value.Value, value.Source = raw, source.name
+				break
+			}
+		}
+		if value.Source == "" {
+			if def, ok := field.tag.Lookup("default"); ok {
+				value.Value, value.Source = def, SourceDefault
+			}
+		}
+
+		if value.Source != "" {
+			if err := setField(field, value.Value); err != nil {
+				loadErrs = append(loadErrs, fmt.Errorf("%s (%s) from %s: %w", field.env, field.key, value.Source, err))
+			}
+		}
+		if field.secret {
+			logger.RegisterSecret(value.Value)
+		}
+		config.values = append(config.values, value)
+	}
+	config.Log.Level = strings.ToLower(config.Log.Level)
+
+	if err := validate(config); err != nil {
+		loadErrs = append(loadErrs, err)
+	}
+	if err := errors.Join(loadErrs...); err != nil {
+		return config, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return config, nil
+}
+
+// field is a leaf value of Config.
+type field struct {
+	env    string
+	key    string
+	secret bool
+	tag    reflect.StructTag
+	value  reflect.Value
+}
+
+func fields(config *Config) []field {
+	var fields []field
+
+	sections := reflect.ValueOf(config).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		sectionType := sections.Type().Field(i)
+		section, ok := sectionType.Tag.Lookup("file")
+		if !ok {
+			continue
+		}
+
+		values := sections.Field(i)
+		for j := 0; j < values.NumField(); j++ {
+			fieldType := values.Type().Field(j)
+			fields = append(fields, field{
+				env:    fieldType.Tag.Get("env"),
+				key:    section + "." + fieldType.Tag.Get("file"),
+				secret: fieldType.Tag.Get("secret") == "true",
+				tag:    fieldType.Tag,
+				value:  values.Field(j),
+			})
+		}
+	}
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField parses raw into the field. Durations are a whole number of the
+// field's unit, or a Go duration such as 90s.
+func setField(field field, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch value := field.value; {
+	case value.Type() == durationType:
+		if count, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			unit, err := time.ParseDuration(field.tag.Get("unit"))
+			if err != nil {
+				return fmt.Errorf("invalid unit: %w", err)
+			}
+			value.SetInt(count * int64(unit))
+			return nil
+		}
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("expected a whole number of %s or a duration such as 90s", unitName(field.tag.Get("unit")))
+		}
+		value.SetInt(int64(duration))
+	case value.Kind() == reflect.String:
+		value.SetString(raw)
+	case value.Kind() == reflect.Int, value.Kind() == reflect.Int32, value.Kind() == reflect.Int64:
+		number, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+		value.SetInt(number)
+	case value.Kind() == reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("expected a number")
+		}
+		value.SetFloat(number)
+	case value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+		value.SetBool(b)
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+func unitName(unit string) string {
+	switch unit {
+	case "24h":
+		return "days"
+	case "1m":
+		return "minutes"
+	case "1s":
+		return "seconds"
+	}
+	return unit
+}
+
+// validate checks the rules in the validate tags, reporting every broken rule
+// by environment variable.
+func validate(config *Config) error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("env")
+	})
+	if err := validate.RegisterValidation("origin", isOrigin); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("minbytes", hasMinBytes); err != nil {
+		return err
+	}
+
+	var validationErrs validator.ValidationErrors
+	if err := validate.Struct(config); !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	var errs []error
+	for _, fieldErr := range validationErrs {
+		errs = append(errs, fmt.Errorf("%s: %s", fieldErr.Field(), ruleMessage(fieldErr)))
+	}
+	return errors.Join(errs...)
+}
+
+func ruleMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required unless " + siblingEnv(fieldErr) + " is set"
+	case "numeric":
+		return "must be a number"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "startswith":
+		return fmt.Sprintf("must start with %q", fieldErr.Param())
+	case "contains":
+		return fmt.Sprintf("must contain %q", fieldErr.Param())
+	case "gt":
+		return "must be greater than " + fieldErr.Param()
+	case "gte":
+		return "must be at least " + fieldErr.Param()
+	case "lte":
+		return "must be at most " + fieldErr.Param()
+	case "ltefield":
+		return "must not exceed " + siblingEnv(fieldErr)
+	case "origin":
+		return "must be * or an origin such as https://example.com or https://*.example.com"
+	case "minbytes":
+		return "must be at least " + fieldErr.Param() + " bytes long, such as the output of openssl rand -base64 32"
+	}
+	return fmt.Sprintf("failed the %s rule", fieldErr.Tag())
+}
+
+// isOrigin accepts the CORS origins the cors middleware accepts: *, or a
+// scheme and host with an optional *. subdomain wildcard.
+func isOrigin(fl validator.FieldLevel) bool {
+	origin := strings.TrimSpace(fl.Field().String())
+	if origin == "*" {
+		return true
+	}
+
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && !strings.Contains(u.Host, "*") &&
+		(u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == ""
+}
+
+// hasMinBytes checks the length of a string in bytes, where min counts
+// characters.
+func hasMinBytes(fl validator.FieldLevel) bool {
+	n, err := strconv.Atoi(fl.Param())
+	return err == nil && len(fl.Field().String()) >= n
+}
+
+// siblingEnv is the environment variable of the field a cross-field rule
+// compares with.
+func siblingEnv(fieldErr validator.FieldError) string {
+	path := strings.Split(fieldErr.StructNamespace(), ".")
+	if len(path) != 3 {
+		return fieldErr.Param()
+	}
+	section, ok := reflect.TypeOf(Config{}).FieldByName(path[1])
+	if !ok {
+		return fieldErr.Param()
+	}
+	sibling, ok := section.Type.FieldByName(fieldErr.Param())
+	if !ok {
+		return fieldErr.Param()
+	}
+	return sibling.Tag.Get("env")
+}
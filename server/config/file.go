@@ -0,0 +1,81 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/daffaromero/matesite/server/vault"
+)
+
+// readFile reads a YAML or TOML configuration file, chosen by extension, into
+// values keyed like database.host. Lists become comma-separated values.
+func readFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var tree map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &tree)
+	case ".toml":
+		err = toml.Unmarshal(raw, &tree)
+	default:
+		return nil, fmt.Errorf("unsupported config file type %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]any, values map[string]string) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(key, value, values)
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// readVault reads the configuration secret from Vault, if Vault is configured.
+// The first read connects and logs in.
+func readVault() (map[string]string, error) {
+	client, err := vault.Shared()
+	if err != nil || client == nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	values, err := client.Secrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration from vault: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,11 @@
+package config
+
+import (
+	"time"
+)
+
+// HealthConfig controls how often the readiness checks run to update the gRPC
+// health service. HTTP probes always run the checks afresh.
+type HealthConfig struct {
+	Interval time.Duration `env:"HEALTH_CHECK_INTERVAL_SECONDS" file:"check_interval_seconds" unit:"1s" default:"10" validate:"gt=0"`
+}
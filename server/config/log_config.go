@@ -0,0 +1,6 @@
+package config
+
+type LogConfig struct {
+	Level  string `env:"LOG_LEVEL" file:"level" default:"info" reload:"true" validate:"oneof=debug info warn error"`
+	Format string `env:"LOG_FORMAT" file:"format" default:"json" validate:"oneof=json console"`
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+)
+
+// MetricsConfig is where the Prometheus metrics are served, on a listener of
+// their own so they are not exposed with the public API. An empty Port
+// disables the listener, and an empty Addr means the HTTP address.
+type MetricsConfig struct {
+	Addr string `env:"METRICS_ADDR" file:"addr"`
+	Port string `env:"METRICS_PORT" file:"port" validate:"omitempty,numeric"`
+	Path string `env:"METRICS_PATH" file:"path" default:"/metrics" validate:"startswith=/"`
+}
+
+// MetricsAddress is the address the metrics listener binds, or "" when it is
+// disabled.
+func (c *Config) MetricsAddress() string {
+	if c.Metrics.Port == "" {
+		return ""
+	}
+	addr := c.Metrics.Addr
+	if addr == "" {
+		addr = c.Server.HTTPAddr
+	}
+	return fmt.Sprintf("%s:%s", addr, c.Metrics.Port)
+}
@@ -2,63 +2,106 @@ package config
 
 import (
 	"context"
-	"fmt"
-	"strconv"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/zap"
 
-	logs "github.com/daffaromero/matesite/server/helper/logger"
-	"github.com/daffaromero/matesite/server/utils"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/tracing"
+	"github.com/daffaromero/matesite/server/vault"
 )
 
-var (
-	host               = utils.GetEnv("DB_HOST")
-	port               = utils.GetEnv("DB_PORT")
-	username           = utils.GetEnv("DB_USERNAME")
-	password           = utils.GetEnv("DB_PASSWORD")
-	dbName             = utils.GetEnv("DB_NAME")
-	minConns           = utils.GetEnv("DB_MIN_CONNS")
-	maxConns           = utils.GetEnv("DB_MAX_CONNS")
-	TimeOutDuration, _ = strconv.Atoi(utils.GetEnv("DB_CONNECTION_TIMEOUT"))
-)
-
-func NewPostgresDatabase() *pgxpool.Pool {
-	logger := logs.New("database_connection")
-	dsn := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", username, password, host, port, dbName)
+type DatabaseConfig struct {
+	Host     string `env:"DB_HOST" file:"host" validate:"required"`
+	Port     string `env:"DB_PORT" file:"port" default:"5432" validate:"numeric"`
+	Username string `env:"DB_USERNAME" file:"username" validate:"required_without=VaultRole"`
+	Password string `env:"DB_PASSWORD" file:"password" secret:"true"`
+	Name     string `env:"DB_NAME" file:"name" validate:"required"`
+	MinConns int32  `env:"DB_MIN_CONNS" file:"min_conns" default:"0" validate:"gte=0,ltefield=MaxConns"`
+	MaxConns int32  `env:"DB_MAX_CONNS" file:"max_conns" default:"10" validate:"gt=0"`
+	// Timeout bounds every transaction.
+	Timeout time.Duration `env:"DB_CONNECTION_TIMEOUT" file:"timeout_seconds" unit:"1s" default:"10" validate:"gt=0"`
+	// VaultRole, when set, is the role of the Vault database secrets engine
+	// mounted at VaultMount that the username and password are leased from,
+	// in place of Username and Password. Leases are taken with orphan tokens,
+	// which the Vault policy must allow with sudo on
+	// auth/token/create-orphan.
+	VaultRole  string `env:"DB_VAULT_ROLE" file:"vault_role"`
+	VaultMount string `env:"DB_VAULT_MOUNT" file:"vault_mount" default:"database"`
+}
 
-	poolConfig, err := pgxpool.ParseConfig(dsn)
-	if err != nil {
-		logger.Error("Failed to parse configuration dsn " + dsn)
+// DSN is the connection URL of the database. It has no user when the
+// credentials are leased from Vault.
+func (c DatabaseConfig) DSN() string {
+	dsn := url.URL{
+		Scheme: "postgresql",
+		Host:   net.JoinHostPort(c.Host, c.Port),
+		Path:   "/" + c.Name,
 	}
-
-	minConnsInt, err := strconv.Atoi(minConns)
-	if err != nil {
-		logger.Error("DB_MIN_CONNS expected to be integer minimum connections " + minConns)
+	if c.Username != "" {
+		dsn.User = url.UserPassword(c.Username, c.Password)
 	}
-	maxConnsInt, err := strconv.Atoi(maxConns)
+	return dsn.String()
+}
+
+// NewPostgresDatabase opens a connection pool. When credentials is not nil,
+// every connection is made with the credentials current at the time, and the
+// pool is reset whenever they are rotated: idle connections are closed at once
+// and busy ones when they are released, so that queries in flight finish. The
+// connections open with each lease are reported to credentials, which keeps
+// a replaced lease until the last of them is closed.
+func NewPostgresDatabase(config DatabaseConfig, credentials *vault.DatabaseCredentials) (*pgxpool.Pool, error) {
+	// The DSN carries the password, so only where the database is gets logged.
+	log := logger.Default().Named("database_connection").With(
+		zap.String("host", config.Host),
+		zap.String("port", config.Port),
+		zap.String("database", config.Name),
+	)
+
+	poolConfig, err := pgxpool.ParseConfig(config.DSN())
 	if err != nil {
-		logger.Error("DB_MAX_CONNS expected to be integer maximum connections" + maxConns)
+		log.Error("Failed to parse configuration", zap.Error(err))
+		return nil, err
 	}
 
-	poolConfig.MinConns = int32(minConnsInt)
-	poolConfig.MaxConns = int32(maxConnsInt)
+	poolConfig.MinConns = config.MinConns
+	poolConfig.MaxConns = config.MaxConns
 	poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+	if credentials != nil {
+		poolConfig.BeforeConnect = func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+			current := credentials.Current()
+			connConfig.User, connConfig.Password = current.Username, current.Password
+			return nil
+		}
+		poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+			return credentials.ConnectionOpened(conn.Config().User)
+		}
+		poolConfig.BeforeClose = func(conn *pgx.Conn) {
+			credentials.ConnectionClosed(conn.Config().User)
+		}
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
-		logger.Error("Failed to apply pool configuration dsn " + dsn)
+		log.Error("Failed to apply pool configuration", zap.Error(err))
+		return nil, err
+	}
+	if credentials != nil {
+		credentials.Subscribe(func(vault.Credentials) { pool.Reset() })
 	}
 
 	c, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := pool.Ping(c); err != nil {
-		logger.Error(err)
+		log.Error("Failed to ping database", zap.Error(err))
+	} else {
+		log.Info("Database connected")
 	}
 
-	logger.Log("Database connected on " + dsn)
-
-	return pool
+	return pool, nil
 }
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/vault"
+	"go.uber.org/zap"
+)
+
+// Change is a configuration value that differs from the one in use. Secret
+// values are redacted.
+type Change struct {
+	Env    string `json:"env"`
+	Key    string `json:"key"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	Source string `json:"source"`
+}
+
+// ReloadResult lists what a reload changed.
+type ReloadResult struct {
+	// Applied are the changed values that are reloadable, which are now in
+	// use.
+	Applied []Change `json:"applied"`
+	// RestartRequired are the changed values that are only read at startup,
+	// such as listen addresses. They are not applied.
+	RestartRequired []Change `json:"restart_required"`
+}
+
+// Reloader holds the configuration in use and re-reads it on request. Only the
+// values tagged reload:"true" are swapped in; the rest keep their startup
+// values until the process restarts.
+type Reloader struct {
+	path    string
+	current atomic.Pointer[Config]
+
+	// mu serializes reloads, and guards subscribers.
+	mu          sync.Mutex
+	subscribers []func(*Config)
+}
+
+// NewReloader returns a reloader starting from config, which was loaded from
+// path.
+func NewReloader(path string, config *Config) *Reloader {
+	r := &Reloader{path: path}
+	r.current.Store(config)
+	return r
+}
+
+// Current returns the configuration in use. It must not be modified.
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// Subscribe calls fn with the new configuration after every reload that
+// applies a change. Subscribers are called in the order they subscribed, and
+// never concurrently.
+func (r *Reloader) Subscribe(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload reads the configuration from every source again, as Load does, with
+// the Vault secret read afresh rather than from cache. An invalid
+// configuration is rejected as a whole and the current one is kept. Otherwise
+// the reloadable values that changed are swapped in at once and the
+// subscribers notified. The outcome is logged through the logger carried by
+// ctx.
+func (r *Reloader) Reload(ctx context.Context) (ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A reload is asked for after a change, which must not be hidden by the
+	// cached Vault secret.
+	if client, err := vault.Shared(); err == nil && client != nil {
+		client.Invalidate()
+	}
+
+	loaded, err := Load(r.path)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to reload configuration, keeping the current one", zap.Error(err))
+		return ReloadResult{}, err
+	}
+
+	current := r.current.Load()
+	next := *current
+	next.values = slices.Clone(current.values)
+
+	result := ReloadResult{Applied: []Change{}, RestartRequired: []Change{}}
+	currentFields, loadedFields, nextFields := fields(current), fields(loaded), fields(&next)
+	for i, field := range nextFields {
+		if reflect.DeepEqual(currentFields[i].value.Interface(), loadedFields[i].value.Interface()) {
+			continue
+		}
+
+		change := Change{
+			Env:    field.env,
+			Key:    field.key,
+			Old:    current.values[i].Value,
+			New:    loaded.values[i].Value,
+			Source: loaded.values[i].Source,
+		}
+		if field.secret {
+			change.Old, change.New = logger.Redacted, logger.Redacted
+		}
+
+		if field.tag.Get("reload") != "true" {
+			result.RestartRequired = append(result.RestartRequired, change)
+			continue
+		}
+		field.value.Set(loadedFields[i].value)
+		next.values[i] = loaded.values[i]
+		result.Applied = append(result.Applied, change)
+	}
+
+	if len(result.RestartRequired) > 0 {
+		logger.FromContext(ctx).Warn("Configuration changes require a restart to take effect", zap.Any("changes", result.RestartRequired))
+	}
+	if len(result.Applied) == 0 {
+		logger.FromContext(ctx).Info("Reloaded configuration, nothing to apply")
+		return result, nil
+	}
+
+	// Logged first, so that the line is written at the level in use before a
+	// subscriber changes it.
+	logger.FromContext(ctx).Info("Reloaded configuration", zap.Any("changes", result.Applied))
+	r.current.Store(&next)
+	for _, subscriber := range r.subscribers {
+		subscriber(&next)
+	}
+	return result, nil
+}
@@ -2,37 +2,32 @@ package config
 
 import (
 	"fmt"
-	"log"
-
-	"github.com/daffaromero/matesite/server/utils"
 )
 
-var EndpointPrefix = utils.GetEnv("ENDPOINT_PREFIX")
-
 type ServerConfig struct {
-	HTTP     string
-	HTTPAddr string
-	HTTPPort string
-	Name     string
+	HTTPAddr string `env:"HTTP_ADDR" file:"http_addr" validate:"required"`
+	HTTPPort string `env:"HTTP_PORT" file:"http_port" validate:"required,numeric"`
+	GRPCPort string `env:"GRPC_PORT" file:"grpc_port" validate:"required,numeric"`
+	Name     string `env:"SERVICE_NAME" file:"name" validate:"required"`
+}
+
+// HTTP is the address the HTTP API listens on.
+func (c ServerConfig) HTTP() string {
+	return fmt.Sprintf("%s:%s", c.HTTPAddr, c.HTTPPort)
+}
+
+// GRPC is the address the gRPC API listens on.
+func (c ServerConfig) GRPC() string {
+	return fmt.Sprintf("%s:%s", c.HTTPAddr, c.GRPCPort)
 }
 
-func NewServerConfig() ServerConfig {
-	httpAddr := utils.GetEnv("HTTP_ADDR")
-	if httpAddr == "" {
-		log.Fatal("HTTP_ADDR environment variable is not set")
-	}
-	port := utils.GetEnv("HTTP_PORT")
-	if port == "" {
-		log.Fatal("HTTP_PORT environment variable is not set")
-	}
-	name := utils.GetEnv("SERVICE_NAME")
-	if name == "" {
-		log.Fatal("SERVICE_NAME environment variable is not set")
-	}
-	return ServerConfig{
-		HTTP:     fmt.Sprintf("%s:%s", httpAddr, port),
-		HTTPAddr: httpAddr,
-		HTTPPort: port,
-		Name:     name,
-	}
+// EndpointConfig is where the HTTP routes are mounted.
+type EndpointConfig struct {
+	// Issues is where issue routes are mounted. Issues live inside a
+	// project, so it must contain the :project parameter.
+	Issues   string `env:"ENDPOINT_PREFIX" file:"issues" default:"/projects/:project/issues" validate:"required,startswith=/,contains=:project"`
+	Projects string `env:"PROJECTS_ENDPOINT_PREFIX" file:"projects" default:"/projects" validate:"required,startswith=/"`
+	Labels   string `env:"LABELS_ENDPOINT_PREFIX" file:"labels" default:"/labels" validate:"required,startswith=/"`
+	Users    string `env:"USERS_ENDPOINT_PREFIX" file:"users" default:"/users" validate:"required,startswith=/"`
+	Admin    string `env:"ADMIN_ENDPOINT_PREFIX" file:"admin" default:"/admin" validate:"required,startswith=/"`
 }
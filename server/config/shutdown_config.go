@@ -0,0 +1,13 @@
+package config
+
+import (
+	"time"
+)
+
+// ShutdownConfig controls how the server stops on SIGINT or SIGTERM. Once
+// readiness has been reported as failing for DrainDelay, so load balancers
+// stop routing new requests, in-flight requests have Timeout to finish.
+type ShutdownConfig struct {
+	DrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY_SECONDS" file:"drain_delay_seconds" unit:"1s" default:"0" validate:"gte=0"`
+	Timeout    time.Duration `env:"SHUTDOWN_TIMEOUT_SECONDS" file:"timeout_seconds" unit:"1s" default:"30" validate:"gt=0"`
+}
@@ -0,0 +1,10 @@
+package config
+
+// TracingConfig selects where spans are exported: none, otlp or stdout. The
+// OTLP exporter reads the standard OTEL_EXPORTER_OTLP_* variables, and the
+// stdout exporter writes to File when it is set.
+type TracingConfig struct {
+	Exporter    string  `env:"TRACING_EXPORTER" file:"exporter" default:"none" validate:"oneof=none otlp stdout"`
+	File        string  `env:"TRACING_FILE" file:"file"`
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" file:"sample_ratio" default:"1" validate:"gte=0,lte=1"`
+}
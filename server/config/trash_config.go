@@ -0,0 +1,13 @@
+package config
+
+import (
+	"time"
+)
+
+// TrashConfig controls how long soft-deleted issues are kept before the
+// retention job purges them. A zero Retention disables the job.
+type TrashConfig struct {
+	Retention      time.Duration `env:"TRASH_RETENTION_DAYS" file:"retention_days" unit:"24h" default:"30" reload:"true" validate:"gte=0"`
+	PurgeInterval  time.Duration `env:"TRASH_PURGE_INTERVAL_MINUTES" file:"purge_interval_minutes" unit:"1m" default:"60" reload:"true" validate:"gt=0"`
+	PurgeBatchSize int           `env:"TRASH_PURGE_BATCH_SIZE" file:"purge_batch_size" default:"500" reload:"true" validate:"gt=0"`
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/helper/logger"
+)
+
+// configCommand runs the config subcommands. config print lists every value
+// with its environment variable, file key and source; --redact masks secrets.
+func configCommand(args []string, configPath string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	redact := flags.Bool("redact", false, "mask secret values")
+	flags.StringVar(&configPath, "config", configPath, "YAML or TOML configuration file")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+
+	cfg, loadErr := config.Load(configPath)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENV\tKEY\tVALUE\tSOURCE")
+	for _, value := range cfg.Values() {
+		shown, source := value.Value, value.Source
+		if *redact && value.Secret && shown != "" {
+			shown = logger.Redacted
+		}
+		if source == "" {
+			source = "unset"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", value.Env, value.Key, shown, source)
+	}
+	if err := w.Flush(); err != nil {
+		return exitFailure
+	}
+
+	if loadErr != nil {
+		fmt.Fprintln(os.Stderr, loadErr)
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+)
+
+type CommentController interface {
+	Route(*fiber.App)
+	GetComment(ctx fiber.Ctx) error
+	ListComments(ctx fiber.Ctx) error
+	ListCommentRevisions(ctx fiber.Ctx) error
+	CreateComment(ctx fiber.Ctx) error
+	UpdateComment(ctx fiber.Ctx) error
+	DeleteComment(ctx fiber.Ctx) error
+}
+
+type commentController struct {
+	validate  *validator.Validate
+	service   service.CommentService
+	endpoints config.EndpointConfig
+}
+
+func NewCommentController(validate *validator.Validate, service service.CommentService, endpoints config.EndpointConfig) CommentController {
+	return &commentController{
+		validate:  validate,
+		service:   service,
+		endpoints: endpoints,
+	}
+}
+
+func (c *commentController) Route(app *fiber.App) {
+	comments := app.Group(c.endpoints.Issues + "/:id/comments")
+	comments.Get("/", c.ListComments)
+	comments.Post("/", c.CreateComment)
+	comments.Get("/:comment", c.GetComment)
+	comments.Put("/:comment", c.UpdateComment)
+	comments.Delete("/:comment", c.DeleteComment)
+	comments.Get("/:comment/history", c.ListCommentRevisions)
+}
+
+func (c *commentController) GetComment(ctx fiber.Ctx) error {
+	var req api.GetCommentRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.Id = ctx.Params("comment")
+	if req.IssueId == "" || req.Id == "" {
+		return errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.service.GetComment(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *commentController) ListComments(ctx fiber.Ctx) error {
+	var req api.ListCommentsRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	if req.IssueId == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+	req.PageToken = ctx.Query("page_token")
+
+	if pageSize := ctx.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			return errs.InvalidField("page_size", "page_size must be a non-negative integer")
+		}
+		req.PageSize = int32(size)
+	}
+
+	res, err := c.service.ListComments(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *commentController) ListCommentRevisions(ctx fiber.Ctx) error {
+	var req api.ListCommentRevisionsRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.Id = ctx.Params("comment")
+	if req.IssueId == "" || req.Id == "" {
+		return errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.service.ListCommentRevisions(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *commentController) CreateComment(ctx fiber.Ctx) error {
+	issueID := ctx.Params("id")
+	if issueID == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+
+	var req api.CreateCommentRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	if req.Comment == nil {
+		return errs.InvalidArgument("comment is required")
+	}
+	req.Comment.IssueId = issueID
+	req.ProjectId = ctx.Params("project")
+
+	res, err := c.service.CreateComment(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(res)
+}
+
+func (c *commentController) UpdateComment(ctx fiber.Ctx) error {
+	issueID := ctx.Params("id")
+	id := ctx.Params("comment")
+	if issueID == "" || id == "" {
+		return errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	var req api.UpdateCommentRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if req.Comment == nil {
+		return errs.InvalidArgument("comment cannot be empty")
+	}
+	req.Comment.IssueId = issueID
+	req.ProjectId = ctx.Params("project")
+	req.Comment.Id = id
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	res, err := c.service.UpdateComment(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *commentController) DeleteComment(ctx fiber.Ctx) error {
+	var req api.DeleteCommentRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.Id = ctx.Params("comment")
+	if req.IssueId == "" || req.Id == "" {
+		return errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.service.DeleteComment(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/service"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ConfigController interface {
+	Route(*fiber.App)
+	ReloadConfig(ctx fiber.Ctx) error
+}
+
+type configController struct {
+	service   service.ConfigService
+	endpoints config.EndpointConfig
+}
+
+func NewConfigController(service service.ConfigService, endpoints config.EndpointConfig) ConfigController {
+	return &configController{
+		service:   service,
+		endpoints: endpoints,
+	}
+}
+
+func (c *configController) Route(app *fiber.App) {
+	admin := app.Group(c.endpoints.Admin)
+	admin.Post("/config/reload", c.ReloadConfig)
+}
+
+// ReloadConfig reloads the configuration as SIGHUP does, and reports which
+// changes were applied and which need a restart.
+func (c *configController) ReloadConfig(ctx fiber.Ctx) error {
+	res, err := c.service.ReloadConfig(ctx.UserContext())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorBody is the JSON body of every HTTP error response.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code            errs.Code             `json:"code"`
+	Message         string                `json:"message"`
+	RequestID       string                `json:"request_id,omitempty"`
+	FieldViolations []errs.FieldViolation `json:"field_violations,omitempty"`
+}
+
+// ErrorHandler is the Fiber error handler. It writes domain errors, and the
+// errors Fiber raises itself, as an errorBody with the matching status.
+// Messages are redacted, as some carry the text of lower-level errors.
+func ErrorHandler(ctx fiber.Ctx, err error) error {
+	domainErr := errs.From(err)
+	statusCode := domainErr.Code.HTTPStatus()
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		domainErr = errs.New(errs.CodeForHTTPStatus(fiberErr.Code), fiberErr.Message)
+		statusCode = fiberErr.Code
+	}
+
+	return ctx.Status(statusCode).JSON(errorBody{
+		Error: errorDetail{
+			Code:            domainErr.Code,
+			Message:         logger.Redact(domainErr.Message),
+			RequestID:       requestid.FromContext(ctx),
+			FieldViolations: domainErr.Violations,
+		},
+	})
+}
+
+// UnaryErrorInterceptor is the gRPC counterpart of ErrorHandler. It turns
+// domain errors into status errors carrying ErrorInfo, RequestInfo and, for
+// invalid fields, BadRequest details. It must run inside
+// logger.UnaryServerInterceptor, which sets the request ID.
+func UnaryErrorInterceptor(domain string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		domainErr := errs.From(err)
+		st := status.New(domainErr.Code.GRPCCode(), logger.Redact(domainErr.Message))
+
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		details := []protoadapt.MessageV1{
+			&errdetails.ErrorInfo{Reason: string(domainErr.Code), Domain: domain},
+			&errdetails.RequestInfo{RequestId: logger.RequestID(ctx)},
+		}
+		if len(badRequest.FieldViolations) > 0 {
+			details = append(details, badRequest)
+		}
+		if detailed, detailErr := st.WithDetails(details...); detailErr == nil {
+			st = detailed
+		}
+		return nil, st.Err()
+	}
+}
+
+// validationError reports the failures of a validator as field violations.
+func validationError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	domainErr := errs.InvalidArgument("Request validation failed.")
+	for _, fieldErr := range validationErrs {
+		field := fieldErr.Namespace()
+		if _, nested, ok := strings.Cut(field, "."); ok {
+			field = nested
+		}
+		domainErr.Violations = append(domainErr.Violations, errs.FieldViolation{
+			Field:       field,
+			Description: fieldErr.Error(),
+		})
+	}
+	return domainErr
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"github.com/daffaromero/matesite/server/health"
+	"github.com/gofiber/fiber/v3"
+)
+
+type HealthController interface {
+	Route(*fiber.App)
+	Liveness(ctx fiber.Ctx) error
+	Readiness(ctx fiber.Ctx) error
+}
+
+type healthController struct {
+	checker *health.Checker
+}
+
+func NewHealthController(checker *health.Checker) HealthController {
+	return &healthController{
+		checker: checker,
+	}
+}
+
+// Route mounts the probes. They are unauthenticated, so they must be routed
+// before the auth middleware is registered.
+func (c *healthController) Route(app *fiber.App) {
+	app.Get("/healthz", c.Liveness)
+	app.Get("/readyz", c.Readiness)
+}
+
+// Liveness reports that the process is up and serving HTTP.
+func (c *healthController) Liveness(ctx fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(health.Report{Status: health.StatusOK})
+}
+
+// Readiness reports whether every dependency is usable, with the result of
+// each check.
+func (c *healthController) Readiness(ctx fiber.Ctx) error {
+	report := c.checker.Check(ctx.UserContext())
+	if !report.Ready() {
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
@@ -1,64 +1,140 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
 	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
 	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository/query"
 	"github.com/daffaromero/matesite/server/service"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v3"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+const mergePatchContentType = "application/merge-patch+json"
+
 type IssueController interface {
 	Route(*fiber.App)
 	GetIssue(ctx fiber.Ctx) error
 	ListIssues(ctx fiber.Ctx) error
 	CreateIssue(ctx fiber.Ctx) error
 	UpdateIssue(ctx fiber.Ctx) error
+	PatchIssue(ctx fiber.Ctx) error
 	DeleteIssue(ctx fiber.Ctx) error
+	TransitionIssue(ctx fiber.Ctx) error
+	ListIssueEvents(ctx fiber.Ctx) error
+	ListDeletedIssues(ctx fiber.Ctx) error
+	RestoreIssue(ctx fiber.Ctx) error
+	PurgeIssue(ctx fiber.Ctx) error
 }
 
 type issueController struct {
-	validate *validator.Validate
-	service  service.IssueService
+	validate  *validator.Validate
+	service   service.IssueService
+	endpoints config.EndpointConfig
 }
 
-func NewIssueController(validate *validator.Validate, service service.IssueService) IssueController {
+func NewIssueController(validate *validator.Validate, service service.IssueService, endpoints config.EndpointConfig) IssueController {
 	return &issueController{
-		validate: validate,
-		service:  service,
+		validate:  validate,
+		service:   service,
+		endpoints: endpoints,
 	}
 }
 
 func (c *issueController) Route(app *fiber.App) {
-	api := app.Group(config.EndpointPrefix)
+	api := app.Group(c.endpoints.Issues)
+	api.Get("/trash", c.ListDeletedIssues)
 	api.Get("/:id", c.GetIssue)
 	api.Get("/", c.ListIssues)
 	api.Post("/new", c.CreateIssue)
 	api.Put("/:id", c.UpdateIssue)
+	api.Patch("/:id", c.PatchIssue)
 	api.Delete("/:id", c.DeleteIssue)
+	api.Post("/:id/transition", c.TransitionIssue)
+	api.Get("/:id/history", c.ListIssueEvents)
+	api.Post("/:id/restore", c.RestoreIssue)
+	api.Delete("/:id/purge", c.PurgeIssue)
 }
 
 func (c *issueController) GetIssue(ctx fiber.Ctx) error {
 	var req api.GetIssueRequest
 	req.Id = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
 	if req.Id == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue id not provided"})
+		return errs.InvalidArgument("issue id not provided")
 	}
 
-	res, err := c.service.GetIssue(ctx.Context(), &req)
+	res, err := c.service.GetIssue(ctx.UserContext(), &req)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+
+	etag := issueETag(res.Issue)
+	ctx.Set(fiber.HeaderETag, etag)
+	if etagMatches(ctx.Get(fiber.HeaderIfNoneMatch), etag) {
+		return ctx.SendStatus(fiber.StatusNotModified)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(res)
 }
 
 func (c *issueController) ListIssues(ctx fiber.Ctx) error {
-	var req *api.ListIssuesRequest
+	var req api.ListIssuesRequest
+	req.ProjectId = ctx.Params("project")
+	req.Search = ctx.Query("q")
+	req.Assignee = ctx.Query("assignee")
+	req.Reporter = ctx.Query("reporter")
+	req.PageToken = ctx.Query("page_token")
+
+	if pageSize := ctx.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			return errs.InvalidField("page_size", "page_size must be a non-negative integer")
+		}
+		req.PageSize = int32(size)
+	}
+
+	if includeTotal := ctx.Query("include_total"); includeTotal != "" {
+		include, err := strconv.ParseBool(includeTotal)
+		if err != nil {
+			return errs.InvalidField("include_total", "include_total must be a boolean")
+		}
+		req.IncludeTotal = include
+	}
+
+	for _, param := range ctx.Request().URI().QueryArgs().PeekMulti("status") {
+		for _, name := range strings.Split(string(param), ",") {
+			status, err := query.ParseIssueStatus(name)
+			if err != nil {
+				return err
+			}
+			req.Statuses = append(req.Statuses, status)
+		}
+	}
+
+	for _, param := range ctx.Request().URI().QueryArgs().PeekMulti("label") {
+		req.Labels = append(req.Labels, strings.Split(string(param), ",")...)
+	}
+
+	switch ctx.Query("label_match") {
+	case "", "all":
+		req.LabelMatch = api.LabelMatch_LABEL_MATCH_ALL
+	case "any":
+		req.LabelMatch = api.LabelMatch_LABEL_MATCH_ANY
+	default:
+		return errs.InvalidField("label_match", "label_match must be either all or any")
+	}
 
-	res, err := c.service.ListIssues(ctx.Context(), req)
+	res, err := c.service.ListIssues(ctx.UserContext(), &req)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(res)
@@ -68,24 +144,26 @@ func (c *issueController) CreateIssue(ctx fiber.Ctx) error {
 	var req api.CreateIssueRequest
 	err := ctx.Bind().Body(&req)
 	if err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return errs.InvalidArgument(err.Error())
 	}
 
 	if err := c.validate.Struct(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return validationError(err)
 	}
 
 	if req.Issue == nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue is required"})
+		return errs.InvalidArgument("issue is required")
 	}
+	req.Issue.ProjectId = ctx.Params("project")
 
 	title := req.Issue.Title
 	description := req.Issue.Description
 
-	res, err := c.service.CreateIssue(ctx.Context(), &req, title, description)
+	res, err := c.service.CreateIssue(ctx.UserContext(), &req, title, description)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
+	ctx.Set(fiber.HeaderETag, issueETag(res.Issue))
 
 	return ctx.Status(fiber.StatusCreated).JSON(res)
 }
@@ -93,31 +171,100 @@ func (c *issueController) CreateIssue(ctx fiber.Ctx) error {
 func (c *issueController) UpdateIssue(ctx fiber.Ctx) error {
 	id := ctx.Params("id")
 	if id == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue_id not provided"})
+		return errs.InvalidArgument("issue_id not provided")
 	}
 
 	var req api.UpdateIssueRequest
 	if err := ctx.Bind().Body(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return errs.InvalidArgument(err.Error())
 	}
 
 	if req.Issue == nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue cannot be empty"})
+		return errs.InvalidArgument("issue cannot be empty")
 	}
 	req.Issue.Id = id
+	req.Issue.ProjectId = ctx.Params("project")
 
 	if err := c.validate.Struct(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return validationError(err)
+	}
+
+	if len(req.GetUpdateMask().GetPaths()) == 0 && req.Issue.Title == "" && req.Issue.Description == "" {
+		return errs.InvalidArgument("At least one field (title or description) must be provided for update")
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.ExpectedVersion = version
+
+	res, err := c.service.UpdateIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderETag, issueETag(res.Issue))
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+// PatchIssue applies a JSON merge patch (RFC 7396) to an issue. Members that
+// are left out are kept, and members set to null are cleared.
+func (c *issueController) PatchIssue(ctx fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return errs.InvalidArgument("issue_id not provided")
+	}
+
+	mediaType, _, err := mime.ParseMediaType(ctx.Get(fiber.HeaderContentType))
+	if err != nil || mediaType != mergePatchContentType {
+		return errs.New(errs.CodeUnsupportedMediaType, "Content-Type must be "+mergePatchContentType)
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(ctx.Body(), &patch); err != nil || patch == nil {
+		return errs.InvalidArgument("merge patch must be a JSON object")
+	}
+
+	req := api.UpdateIssueRequest{
+		Issue:      &api.Issue{Id: id, ProjectId: ctx.Params("project")},
+		UpdateMask: &fieldmaskpb.FieldMask{},
 	}
+	for field, raw := range patch {
+		var target *string
+		switch field {
+		case "title":
+			target = &req.Issue.Title
+		case "description":
+			target = &req.Issue.Description
+		default:
+			return errs.InvalidField(field, fmt.Sprintf("field %s cannot be patched", field))
+		}
 
-	if req.Issue.Title == "" && req.Issue.Description == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "At least one field (title or description) must be provided for update"})
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return errs.InvalidField(field, fmt.Sprintf("%s must be a string or null", field))
+		}
+		if value != nil {
+			*target = *value
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+	if len(req.UpdateMask.Paths) == 0 {
+		return errs.InvalidArgument("merge patch must change at least one field")
 	}
 
-	res, err := c.service.UpdateIssue(ctx.Context(), &req, req.Issue.Title, req.Issue.Description)
+	version, err := ifMatchVersion(ctx)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
+	req.ExpectedVersion = version
+
+	res, err := c.service.UpdateIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderETag, issueETag(res.Issue))
 
 	return ctx.Status(fiber.StatusOK).JSON(res)
 }
@@ -125,14 +272,165 @@ func (c *issueController) UpdateIssue(ctx fiber.Ctx) error {
 func (c *issueController) DeleteIssue(ctx fiber.Ctx) error {
 	var req api.DeleteIssueRequest
 	req.Id = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
 	if req.Id == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "issue id not provided"})
+		return errs.InvalidArgument("issue id not provided")
 	}
 
-	res, err := c.service.DeleteIssue(ctx.Context(), &req)
+	version, err := ifMatchVersion(ctx)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	req.ExpectedVersion = version
+
+	res, err := c.service.DeleteIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(res)
 }
+
+func (c *issueController) TransitionIssue(ctx fiber.Ctx) error {
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := ctx.Bind().Body(&body); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	var req api.TransitionIssueRequest
+	req.Id = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	if req.Id == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+
+	status, err := query.ParseIssueStatus(body.Status)
+	if err != nil {
+		return err
+	}
+	req.Status = status
+
+	res, err := c.service.TransitionIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderETag, issueETag(res.Issue))
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *issueController) ListIssueEvents(ctx fiber.Ctx) error {
+	var req api.ListIssueEventsRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	if req.IssueId == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+	req.PageToken = ctx.Query("page_token")
+
+	if pageSize := ctx.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			return errs.InvalidField("page_size", "page_size must be a non-negative integer")
+		}
+		req.PageSize = int32(size)
+	}
+
+	res, err := c.service.ListIssueEvents(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *issueController) ListDeletedIssues(ctx fiber.Ctx) error {
+	var req api.ListDeletedIssuesRequest
+	req.ProjectId = ctx.Params("project")
+	req.PageToken = ctx.Query("page_token")
+
+	if pageSize := ctx.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			return errs.InvalidField("page_size", "page_size must be a non-negative integer")
+		}
+		req.PageSize = int32(size)
+	}
+
+	res, err := c.service.ListDeletedIssues(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *issueController) RestoreIssue(ctx fiber.Ctx) error {
+	var req api.RestoreIssueRequest
+	req.Id = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	if req.Id == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.RestoreIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+	ctx.Set(fiber.HeaderETag, issueETag(res.Issue))
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *issueController) PurgeIssue(ctx fiber.Ctx) error {
+	var req api.PurgeIssueRequest
+	req.Id = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	if req.Id == "" {
+		return errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.PurgeIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+// issueETag is the entity tag an issue is served with: its version, which
+// changes with every write to the issue.
+func issueETag(issue *api.Issue) string {
+	return fmt.Sprintf(`"%d"`, issue.GetVersion())
+}
+
+// ifMatchVersion reads the issue version a write is conditional on from the
+// If-Match header, which must hold exactly one strong ETag.
+func ifMatchVersion(ctx fiber.Ctx) (int64, error) {
+	header := strings.TrimSpace(ctx.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return 0, errs.PreconditionRequired("If-Match header with the ETag of the issue is required.")
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	tag, closed := strings.CutSuffix(tag, `"`)
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if !ok || !closed || err != nil || version <= 0 {
+		return 0, errs.InvalidField(fiber.HeaderIfMatch, "If-Match must hold a single ETag of the issue.")
+	}
+	return version, nil
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison RFC 9110 prescribes for it.
+func etagMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
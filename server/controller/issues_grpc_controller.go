@@ -0,0 +1,587 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/service"
+)
+
+type issueGrpcController struct {
+	api.UnimplementedIssuesServiceServer
+	service        service.IssueService
+	labelService   service.LabelService
+	userService    service.UserService
+	commentService service.CommentService
+	projectService service.ProjectService
+}
+
+func NewIssueGrpcController(service service.IssueService, labelService service.LabelService, userService service.UserService, commentService service.CommentService, projectService service.ProjectService) api.IssuesServiceServer {
+	return &issueGrpcController{
+		service:        service,
+		labelService:   labelService,
+		userService:    userService,
+		commentService: commentService,
+		projectService: projectService,
+	}
+}
+
+func (c *issueGrpcController) GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.GetIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, errs.InvalidField("page_size", "page_size must be a non-negative integer")
+	}
+
+	res, err := c.service.ListIssues(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) CreateIssue(ctx context.Context, req *api.CreateIssueRequest) (*api.CreateIssueResponse, error) {
+	if req.GetIssue() == nil {
+		return nil, errs.InvalidArgument("issue is required")
+	}
+	if req.Issue.ProjectId == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.service.CreateIssue(ctx, req, req.Issue.Title, req.Issue.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error) {
+	if req.GetIssue() == nil {
+		return nil, errs.InvalidArgument("issue cannot be empty")
+	}
+	if req.Issue.ProjectId == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.Issue.Id == "" {
+		return nil, errs.InvalidArgument("issue_id not provided")
+	}
+	if len(req.GetUpdateMask().GetPaths()) == 0 && req.Issue.Title == "" && req.Issue.Description == "" {
+		return nil, errs.InvalidArgument("At least one field (title or description) must be provided for update")
+	}
+	if req.GetExpectedVersion() <= 0 {
+		return nil, errs.InvalidField("expected_version", "expected_version not provided")
+	}
+
+	res, err := c.service.UpdateIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) DeleteIssue(ctx context.Context, req *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+	if req.GetExpectedVersion() <= 0 {
+		return nil, errs.InvalidField("expected_version", "expected_version not provided")
+	}
+
+	res, err := c.service.DeleteIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest) (*api.TransitionIssueResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.TransitionIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error) {
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("label id not provided")
+	}
+
+	res, err := c.labelService.GetLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error) {
+	res, err := c.labelService.ListLabels(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, errs.InvalidField("page_size", "page_size must be a non-negative integer")
+	}
+
+	res, err := c.service.ListIssueEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, errs.InvalidField("page_size", "page_size must be a non-negative integer")
+	}
+
+	res, err := c.service.ListDeletedIssues(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest) (*api.RestoreIssueResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.RestoreIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest) (*api.PurgeIssueResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.service.PurgeIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) CreateProject(ctx context.Context, req *api.CreateProjectRequest) (*api.CreateProjectResponse, error) {
+	if req.GetProject() == nil {
+		return nil, errs.InvalidArgument("project is required")
+	}
+
+	res, err := c.projectService.CreateProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error) {
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.projectService.GetProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListProjects(ctx context.Context, req *api.ListProjectsRequest) (*api.ListProjectsResponse, error) {
+	res, err := c.projectService.ListProjects(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) UpdateProject(ctx context.Context, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error) {
+	if req.GetProject() == nil {
+		return nil, errs.InvalidArgument("project cannot be empty")
+	}
+	if req.Project.Id == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.projectService.UpdateProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) DeleteProject(ctx context.Context, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error) {
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.projectService.DeleteProject(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.projectService.ListProjectMembers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) SetProjectMember(ctx context.Context, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error) {
+	if req.GetProjectId() == "" || req.GetUserId() == "" || req.GetRole() == "" {
+		return nil, errs.InvalidArgument("project id, user id and role must be provided")
+	}
+
+	res, err := c.projectService.SetProjectMember(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) RemoveProjectMember(ctx context.Context, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error) {
+	if req.GetProjectId() == "" || req.GetUserId() == "" {
+		return nil, errs.InvalidArgument("project id and user id must be provided")
+	}
+
+	res, err := c.projectService.RemoveProjectMember(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error) {
+	if req.GetLabel() == nil {
+		return nil, errs.InvalidArgument("label is required")
+	}
+
+	res, err := c.labelService.CreateLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) UpdateLabel(ctx context.Context, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error) {
+	if req.GetLabel() == nil {
+		return nil, errs.InvalidArgument("label cannot be empty")
+	}
+	if req.Label.Id == "" {
+		return nil, errs.InvalidArgument("label id not provided")
+	}
+
+	res, err := c.labelService.UpdateLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) DeleteLabel(ctx context.Context, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error) {
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("label id not provided")
+	}
+
+	res, err := c.labelService.DeleteLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) AddIssueLabel(ctx context.Context, req *api.AddIssueLabelRequest) (*api.IssueLabelsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetLabelId() == "" {
+		return nil, errs.InvalidArgument("issue id and label id must be provided")
+	}
+
+	res, err := c.labelService.AddIssueLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) RemoveIssueLabel(ctx context.Context, req *api.RemoveIssueLabelRequest) (*api.IssueLabelsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetLabelId() == "" {
+		return nil, errs.InvalidArgument("issue id and label id must be provided")
+	}
+
+	res, err := c.labelService.RemoveIssueLabel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error) {
+	if req.GetId() == "" {
+		return nil, errs.InvalidArgument("user id not provided")
+	}
+
+	res, err := c.userService.GetUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error) {
+	res, err := c.userService.ListUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.CreateUserResponse, error) {
+	if req.GetUser() == nil {
+		return nil, errs.InvalidArgument("user is required")
+	}
+
+	res, err := c.userService.CreateUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) SetUserRole(ctx context.Context, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error) {
+	if req.GetId() == "" || req.GetRole() == "" {
+		return nil, errs.InvalidArgument("user id and role must be provided")
+	}
+
+	res, err := c.userService.SetUserRole(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) AssignIssue(ctx context.Context, req *api.AssignIssueRequest) (*api.IssueAssigneesResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetUserId() == "" {
+		return nil, errs.InvalidArgument("issue id and user id must be provided")
+	}
+
+	res, err := c.userService.AssignIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) UnassignIssue(ctx context.Context, req *api.UnassignIssueRequest) (*api.IssueAssigneesResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetUserId() == "" {
+		return nil, errs.InvalidArgument("issue id and user id must be provided")
+	}
+
+	res, err := c.userService.UnassignIssue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.commentService.GetComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, errs.InvalidField("page_size", "page_size must be a non-negative integer")
+	}
+
+	res, err := c.commentService.ListComments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.commentService.ListCommentRevisions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetComment() == nil {
+		return nil, errs.InvalidArgument("comment is required")
+	}
+	if req.Comment.IssueId == "" {
+		return nil, errs.InvalidArgument("issue id not provided")
+	}
+
+	res, err := c.commentService.CreateComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) UpdateComment(ctx context.Context, req *api.UpdateCommentRequest) (*api.UpdateCommentResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetComment() == nil {
+		return nil, errs.InvalidArgument("comment cannot be empty")
+	}
+	if req.Comment.IssueId == "" || req.Comment.Id == "" {
+		return nil, errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.commentService.UpdateComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *issueGrpcController) DeleteComment(ctx context.Context, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, errs.InvalidArgument("project id not provided")
+	}
+	if req.GetIssueId() == "" || req.GetId() == "" {
+		return nil, errs.InvalidArgument("issue id and comment id must be provided")
+	}
+
+	res, err := c.commentService.DeleteComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
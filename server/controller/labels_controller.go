@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+)
+
+type LabelController interface {
+	Route(*fiber.App)
+	GetLabel(ctx fiber.Ctx) error
+	ListLabels(ctx fiber.Ctx) error
+	CreateLabel(ctx fiber.Ctx) error
+	UpdateLabel(ctx fiber.Ctx) error
+	DeleteLabel(ctx fiber.Ctx) error
+	AddIssueLabel(ctx fiber.Ctx) error
+	RemoveIssueLabel(ctx fiber.Ctx) error
+}
+
+type labelController struct {
+	validate  *validator.Validate
+	service   service.LabelService
+	endpoints config.EndpointConfig
+}
+
+func NewLabelController(validate *validator.Validate, service service.LabelService, endpoints config.EndpointConfig) LabelController {
+	return &labelController{
+		validate:  validate,
+		service:   service,
+		endpoints: endpoints,
+	}
+}
+
+func (c *labelController) Route(app *fiber.App) {
+	labels := app.Group(c.endpoints.Labels)
+	labels.Get("/:id", c.GetLabel)
+	labels.Get("/", c.ListLabels)
+	labels.Post("/new", c.CreateLabel)
+	labels.Put("/:id", c.UpdateLabel)
+	labels.Delete("/:id", c.DeleteLabel)
+
+	issues := app.Group(c.endpoints.Issues)
+	issues.Put("/:id/labels/:label", c.AddIssueLabel)
+	issues.Delete("/:id/labels/:label", c.RemoveIssueLabel)
+}
+
+func (c *labelController) GetLabel(ctx fiber.Ctx) error {
+	var req api.GetLabelRequest
+	req.Id = ctx.Params("id")
+	if req.Id == "" {
+		return errs.InvalidArgument("label id not provided")
+	}
+
+	res, err := c.service.GetLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *labelController) ListLabels(ctx fiber.Ctx) error {
+	var req api.ListLabelsRequest
+
+	res, err := c.service.ListLabels(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *labelController) CreateLabel(ctx fiber.Ctx) error {
+	var req api.CreateLabelRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	if req.Label == nil {
+		return errs.InvalidArgument("label is required")
+	}
+
+	res, err := c.service.CreateLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(res)
+}
+
+func (c *labelController) UpdateLabel(ctx fiber.Ctx) error {
+	id := ctx.Params("id")
+	if id == "" {
+		return errs.InvalidArgument("label id not provided")
+	}
+
+	var req api.UpdateLabelRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if req.Label == nil {
+		return errs.InvalidArgument("label cannot be empty")
+	}
+	req.Label.Id = id
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	res, err := c.service.UpdateLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *labelController) DeleteLabel(ctx fiber.Ctx) error {
+	var req api.DeleteLabelRequest
+	req.Id = ctx.Params("id")
+	if req.Id == "" {
+		return errs.InvalidArgument("label id not provided")
+	}
+
+	res, err := c.service.DeleteLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *labelController) AddIssueLabel(ctx fiber.Ctx) error {
+	var req api.AddIssueLabelRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.LabelId = ctx.Params("label")
+	if req.IssueId == "" || req.LabelId == "" {
+		return errs.InvalidArgument("issue id and label id must be provided")
+	}
+
+	res, err := c.service.AddIssueLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *labelController) RemoveIssueLabel(ctx fiber.Ctx) error {
+	var req api.RemoveIssueLabelRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.LabelId = ctx.Params("label")
+	if req.IssueId == "" || req.LabelId == "" {
+		return errs.InvalidArgument("issue id and label id must be provided")
+	}
+
+	res, err := c.service.RemoveIssueLabel(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
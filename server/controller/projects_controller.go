@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ProjectController interface {
+	Route(*fiber.App)
+	GetProject(ctx fiber.Ctx) error
+	ListProjects(ctx fiber.Ctx) error
+	CreateProject(ctx fiber.Ctx) error
+	UpdateProject(ctx fiber.Ctx) error
+	DeleteProject(ctx fiber.Ctx) error
+	ListProjectMembers(ctx fiber.Ctx) error
+	SetProjectMember(ctx fiber.Ctx) error
+	RemoveProjectMember(ctx fiber.Ctx) error
+}
+
+type projectController struct {
+	validate  *validator.Validate
+	service   service.ProjectService
+	endpoints config.EndpointConfig
+}
+
+func NewProjectController(validate *validator.Validate, service service.ProjectService, endpoints config.EndpointConfig) ProjectController {
+	return &projectController{
+		validate:  validate,
+		service:   service,
+		endpoints: endpoints,
+	}
+}
+
+func (c *projectController) Route(app *fiber.App) {
+	projects := app.Group(c.endpoints.Projects)
+	projects.Get("/:project", c.GetProject)
+	projects.Get("/", c.ListProjects)
+	projects.Post("/new", c.CreateProject)
+	projects.Put("/:project", c.UpdateProject)
+	projects.Delete("/:project", c.DeleteProject)
+	projects.Get("/:project/members", c.ListProjectMembers)
+	projects.Put("/:project/members/:user", c.SetProjectMember)
+	projects.Delete("/:project/members/:user", c.RemoveProjectMember)
+}
+
+func (c *projectController) GetProject(ctx fiber.Ctx) error {
+	var req api.GetProjectRequest
+	req.Id = ctx.Params("project")
+	if req.Id == "" {
+		return errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.service.GetProject(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) ListProjects(ctx fiber.Ctx) error {
+	var req api.ListProjectsRequest
+
+	res, err := c.service.ListProjects(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) CreateProject(ctx fiber.Ctx) error {
+	var req api.CreateProjectRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	if req.Project == nil {
+		return errs.InvalidArgument("project is required")
+	}
+
+	res, err := c.service.CreateProject(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(res)
+}
+
+func (c *projectController) UpdateProject(ctx fiber.Ctx) error {
+	id := ctx.Params("project")
+	if id == "" {
+		return errs.InvalidArgument("project id not provided")
+	}
+
+	var req api.UpdateProjectRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if req.Project == nil {
+		return errs.InvalidArgument("project cannot be empty")
+	}
+	req.Project.Id = id
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	res, err := c.service.UpdateProject(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) DeleteProject(ctx fiber.Ctx) error {
+	var req api.DeleteProjectRequest
+	req.Id = ctx.Params("project")
+	if req.Id == "" {
+		return errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.service.DeleteProject(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) ListProjectMembers(ctx fiber.Ctx) error {
+	var req api.ListProjectMembersRequest
+	req.ProjectId = ctx.Params("project")
+	if req.ProjectId == "" {
+		return errs.InvalidArgument("project id not provided")
+	}
+
+	res, err := c.service.ListProjectMembers(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) SetProjectMember(ctx fiber.Ctx) error {
+	var req api.SetProjectMemberRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+	req.ProjectId = ctx.Params("project")
+	req.UserId = ctx.Params("user")
+	if req.ProjectId == "" || req.UserId == "" || req.Role == "" {
+		return errs.InvalidArgument("project id, user id and role must be provided")
+	}
+
+	res, err := c.service.SetProjectMember(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *projectController) RemoveProjectMember(ctx fiber.Ctx) error {
+	var req api.RemoveProjectMemberRequest
+	req.ProjectId = ctx.Params("project")
+	req.UserId = ctx.Params("user")
+	if req.ProjectId == "" || req.UserId == "" {
+		return errs.InvalidArgument("project id and user id must be provided")
+	}
+
+	res, err := c.service.RemoveProjectMember(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v3"
+)
+
+type UserController interface {
+	Route(*fiber.App)
+	GetUser(ctx fiber.Ctx) error
+	ListUsers(ctx fiber.Ctx) error
+	CreateUser(ctx fiber.Ctx) error
+	SetUserRole(ctx fiber.Ctx) error
+	AssignIssue(ctx fiber.Ctx) error
+	UnassignIssue(ctx fiber.Ctx) error
+}
+
+type userController struct {
+	validate  *validator.Validate
+	service   service.UserService
+	endpoints config.EndpointConfig
+}
+
+func NewUserController(validate *validator.Validate, service service.UserService, endpoints config.EndpointConfig) UserController {
+	return &userController{
+		validate:  validate,
+		service:   service,
+		endpoints: endpoints,
+	}
+}
+
+func (c *userController) Route(app *fiber.App) {
+	users := app.Group(c.endpoints.Users)
+	users.Get("/:id", c.GetUser)
+	users.Get("/", c.ListUsers)
+	users.Post("/new", c.CreateUser)
+	users.Put("/:id/role", c.SetUserRole)
+
+	issues := app.Group(c.endpoints.Issues)
+	issues.Put("/:id/assignees/:user", c.AssignIssue)
+	issues.Delete("/:id/assignees/:user", c.UnassignIssue)
+}
+
+func (c *userController) GetUser(ctx fiber.Ctx) error {
+	var req api.GetUserRequest
+	req.Id = ctx.Params("id")
+	if req.Id == "" {
+		return errs.InvalidArgument("user id not provided")
+	}
+
+	res, err := c.service.GetUser(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *userController) ListUsers(ctx fiber.Ctx) error {
+	var req api.ListUsersRequest
+
+	res, err := c.service.ListUsers(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *userController) CreateUser(ctx fiber.Ctx) error {
+	var req api.CreateUserRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+
+	if err := c.validate.Struct(&req); err != nil {
+		return validationError(err)
+	}
+
+	if req.User == nil {
+		return errs.InvalidArgument("user is required")
+	}
+
+	res, err := c.service.CreateUser(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(res)
+}
+
+func (c *userController) SetUserRole(ctx fiber.Ctx) error {
+	var req api.SetUserRoleRequest
+	if err := ctx.Bind().Body(&req); err != nil {
+		return errs.InvalidArgument(err.Error())
+	}
+	req.Id = ctx.Params("id")
+	if req.Id == "" || req.Role == "" {
+		return errs.InvalidArgument("user id and role must be provided")
+	}
+
+	res, err := c.service.SetUserRole(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *userController) AssignIssue(ctx fiber.Ctx) error {
+	var req api.AssignIssueRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.UserId = ctx.Params("user")
+	if req.IssueId == "" || req.UserId == "" {
+		return errs.InvalidArgument("issue id and user id must be provided")
+	}
+
+	res, err := c.service.AssignIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
+
+func (c *userController) UnassignIssue(ctx fiber.Ctx) error {
+	var req api.UnassignIssueRequest
+	req.IssueId = ctx.Params("id")
+	req.ProjectId = ctx.Params("project")
+	req.UserId = ctx.Params("user")
+	if req.IssueId == "" || req.UserId == "" {
+		return errs.InvalidArgument("issue id and user id must be provided")
+	}
+
+	res, err := c.service.UnassignIssue(ctx.UserContext(), &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(res)
+}
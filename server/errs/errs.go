@@ -0,0 +1,179 @@
+// Package errs defines the domain errors reported by the query, repository and
+// service layers. Controllers translate them to HTTP and gRPC responses, so
+// the layers below never pick a transport status themselves.
+package errs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Code classifies an Error. Codes are named after their gRPC counterparts and
+// are what clients see in the code field of an error body.
+type Code string
+
+const (
+	CodeInvalidArgument      Code = "INVALID_ARGUMENT"
+	CodeUnauthenticated      Code = "UNAUTHENTICATED"
+	CodePermissionDenied     Code = "PERMISSION_DENIED"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeConflict             Code = "CONFLICT"
+	CodeFailedPrecondition   Code = "FAILED_PRECONDITION"
+	CodePreconditionFailed   Code = "PRECONDITION_FAILED"
+	CodePreconditionRequired Code = "PRECONDITION_REQUIRED"
+	CodeUnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeCanceled             Code = "CANCELED"
+	CodeDeadlineExceeded     Code = "DEADLINE_EXCEEDED"
+	CodeUnavailable          Code = "UNAVAILABLE"
+	CodeInternal             Code = "INTERNAL"
+)
+
+var codeStatuses = map[Code]struct {
+	http int
+	grpc codes.Code
+}{
+	CodeInvalidArgument:      {http.StatusBadRequest, codes.InvalidArgument},
+	CodeUnauthenticated:      {http.StatusUnauthorized, codes.Unauthenticated},
+	CodePermissionDenied:     {http.StatusForbidden, codes.PermissionDenied},
+	CodeNotFound:             {http.StatusNotFound, codes.NotFound},
+	CodeConflict:             {http.StatusConflict, codes.Aborted},
+	CodeFailedPrecondition:   {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	CodePreconditionFailed:   {http.StatusPreconditionFailed, codes.FailedPrecondition},
+	CodePreconditionRequired: {http.StatusPreconditionRequired, codes.FailedPrecondition},
+	CodeUnsupportedMediaType: {http.StatusUnsupportedMediaType, codes.InvalidArgument},
+	CodeCanceled:             {499, codes.Canceled},
+	CodeDeadlineExceeded:     {http.StatusGatewayTimeout, codes.DeadlineExceeded},
+	CodeUnavailable:          {http.StatusServiceUnavailable, codes.Unavailable},
+	CodeInternal:             {http.StatusInternalServerError, codes.Internal},
+}
+
+// HTTPStatus returns the HTTP status code responses with this code are sent
+// with.
+func (c Code) HTTPStatus() int {
+	if status, ok := codeStatuses[c]; ok {
+		return status.http
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode returns the gRPC status code responses with this code are sent
+// with.
+func (c Code) GRPCCode() codes.Code {
+	if status, ok := codeStatuses[c]; ok {
+		return status.grpc
+	}
+	return codes.Internal
+}
+
+// CodeForHTTPStatus returns the code whose HTTP status is status, for errors
+// raised by the HTTP framework itself.
+func CodeForHTTPStatus(status int) Code {
+	for code, statuses := range codeStatuses {
+		if statuses.http == status {
+			return code
+		}
+	}
+	if status >= 400 && status < 500 {
+		return CodeInvalidArgument
+	}
+	return CodeInternal
+}
+
+// FieldViolation describes why a single field of a request is invalid.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Error is a domain error. Its Message is meant for clients, while details
+// for operators belong in the errors wrapping it.
+type Error struct {
+	Code       Code
+	Message    string
+	Violations []FieldViolation
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func InvalidArgument(message string) *Error {
+	return New(CodeInvalidArgument, message)
+}
+
+// InvalidField is an InvalidArgument error about a single field.
+func InvalidField(field string, description string) *Error {
+	return &Error{
+		Code:       CodeInvalidArgument,
+		Message:    description,
+		Violations: []FieldViolation{{Field: field, Description: description}},
+	}
+}
+
+func Unauthenticated(message string) *Error {
+	return New(CodeUnauthenticated, message)
+}
+
+func PermissionDenied(message string) *Error {
+	return New(CodePermissionDenied, message)
+}
+
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+// FailedPrecondition rejects a request the resource is not in a state to
+// accept, such as a disallowed status transition.
+func FailedPrecondition(message string) *Error {
+	return New(CodeFailedPrecondition, message)
+}
+
+// PreconditionFailed rejects a write whose expected version is stale.
+func PreconditionFailed(message string) *Error {
+	return New(CodePreconditionFailed, message)
+}
+
+// PreconditionRequired rejects a write that does not name the version it
+// expects to replace.
+func PreconditionRequired(message string) *Error {
+	return New(CodePreconditionRequired, message)
+}
+
+func Unavailable(message string) *Error {
+	return New(CodeUnavailable, message)
+}
+
+// From returns the domain error in err's chain. Context errors get their own
+// codes, and anything else is an internal error whose message is withheld
+// from clients.
+func From(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return New(CodeDeadlineExceeded, "The request timed out.")
+	case errors.Is(err, context.Canceled):
+		return New(CodeCanceled, "The request was canceled.")
+	}
+	return New(CodeInternal, "Internal server error.")
+}
+
+// Is reports whether err's chain holds a domain error with the given code.
+func Is(err error, code Code) bool {
+	var domainErr *Error
+	return errors.As(err, &domainErr) && domainErr.Code == code
+}
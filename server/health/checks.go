@@ -0,0 +1,41 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Database checks that a connection can be acquired and pinged.
+func Database(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// Migrations checks that the schema_migrations table kept by golang-migrate
+// is clean and at the latest version.
+func Migrations(pool *pgxpool.Pool, latest uint64) Check {
+	return func(ctx context.Context) error {
+		var version uint64
+		var dirty bool
+		err := pool.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("no migrations applied")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read migration version: %w", err)
+		}
+
+		switch {
+		case dirty:
+			return fmt.Errorf("migration %d failed and left the schema dirty", version)
+		case version < latest:
+			return fmt.Errorf("schema is at version %d, expected %d", version, latest)
+		}
+		return nil
+	}
+}
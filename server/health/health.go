@@ -0,0 +1,166 @@
+// Package health tracks whether the service is ready to serve, from checks of
+// its dependencies, and reports it over HTTP and grpc.health.v1.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Status of a check, or of the service as a whole.
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// checkTimeout bounds each check, so a hung dependency fails its check rather
+// than the probe.
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the outcome of every check.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Ready reports whether the service can serve requests.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+type Checker struct {
+	mu       sync.RWMutex
+	checks   map[string]Check
+	draining atomic.Bool
+	grpc     *health.Server
+	services []string
+}
+
+// NewChecker returns a Checker reporting to grpc.health.v1 for the server as
+// a whole and for each of services.
+func NewChecker(services ...string) *Checker {
+	return &Checker{
+		checks:   make(map[string]Check),
+		grpc:     health.NewServer(),
+		services: append([]string{""}, services...),
+	}
+}
+
+// Add registers a readiness check under name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// GRPCServer is the grpc.health.v1 implementation to register with the gRPC
+// server.
+func (c *Checker) GRPCServer() healthpb.HealthServer {
+	return c.grpc
+}
+
+// Check runs every check concurrently. The service is ready when all pass and
+// it is not draining.
+func (c *Checker) Check(ctx context.Context) Report {
+	if c.draining.Load() {
+		return Report{Status: StatusDraining}
+	}
+
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			result := CheckResult{Status: StatusOK}
+			if err := check(ctx); err != nil {
+				result = CheckResult{Status: StatusFailing, Error: logger.Redact(err.Error())}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFailing
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// Watch runs the checks every interval, publishing the result to
+// grpc.health.v1 and logging changes, until ctx is done or the checker drains.
+func (c *Checker) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var ready *bool
+	for {
+		report := c.Check(ctx)
+		if c.draining.Load() {
+			return
+		}
+		if ready == nil || *ready != report.Ready() {
+			if report.Ready() {
+				logger.FromContext(ctx).Info("Service is ready")
+			} else {
+				logger.FromContext(ctx).Warn("Service is not ready", zap.Any("checks", report.Checks))
+			}
+			isReady := report.Ready()
+			ready = &isReady
+		}
+		c.setServingStatus(report.Ready())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drain marks the service as not ready for good, so that load balancers stop
+// routing to it while in-flight requests finish.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+	c.grpc.Shutdown()
+}
+
+func (c *Checker) setServingStatus(ready bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	for _, service := range c.services {
+		c.grpc.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+)
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the logger stored in ctx, which for requests already
+// carries the request and trace IDs, or the default logger.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey).(*zap.Logger); ok {
+		return l
+	}
+	return Default()
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being
+// served.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the ID of the request being served, if any.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
@@ -1,29 +1,94 @@
 package logger
 
 import (
-	"log"
+	"fmt"
 	"os"
+	"strings"
+	"sync/atomic"
 
-	"github.com/daffaromero/matesite/server/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-var logLevel = utils.GetEnv("LOG_LEVEL")
+// Encodings a logger can write its entries in.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
 
-type Log struct {
-	stdout *log.Logger
-	stderr *log.Logger
+// Config describes how a logger writes its entries.
+type Config struct {
+	// Level is the minimum level written: debug, info, warn or error. The
+	// level is shared by every logger built by New, see SetLevel; when empty,
+	// it is left as it is, info at first.
+	Level string
+	// Format is FormatJSON, the default, or FormatConsole.
+	Format string
+	// Service is attached to every entry as the service field.
+	Service string
 }
 
-type Options struct {
-	IsPrintStack bool
-	IsExit       bool
-	ExitCode     int
+var (
+	defaultLogger atomic.Pointer[zap.Logger]
+	level         = zap.NewAtomicLevel()
+)
+
+func init() {
+	l, _ := New(Config{})
+	defaultLogger.Store(l)
 }
 
-func New(prefix string) *Log {
+// New builds a leveled, structured logger writing to stdout. Secrets are
+// redacted from every entry, see Redact.
+func New(config Config) (*zap.Logger, error) {
+	if config.Level != "" {
+		if err := SetLevel(config.Level); err != nil {
+			return nil, err
+		}
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "time"
+	encoderConfig.NameKey = "component"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	switch config.Format {
+	case "", FormatJSON:
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case FormatConsole:
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected %s or %s", config.Format, FormatJSON, FormatConsole)
+	}
+
+	core := redactCore{zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), level)}
+	l := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.DPanicLevel), zap.ErrorOutput(zapcore.Lock(os.Stderr)))
+	if config.Service != "" {
+		l = l.With(zap.String("service", config.Service))
+	}
+	return l, nil
+}
 
-	return &Log{
-		stdout: log.New(os.Stdout, "[LOG]["+prefix+"]", log.Ldate|log.Ltime),
-		stderr: log.New(os.Stderr, "[ERROR]["+prefix+"]", log.Ldate|log.Ltime),
+// SetLevel changes the minimum level of every logger built by New, while they
+// are in use.
+func SetLevel(name string) error {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+		return fmt.Errorf("invalid log level %q", name)
 	}
+	level.SetLevel(l)
+	return nil
+}
+
+// Default returns the process-wide logger, used where no request context is
+// at hand. Until SetDefault is called it logs JSON at info level.
+func Default() *zap.Logger {
+	return defaultLogger.Load()
+}
+
+// SetDefault replaces the process-wide logger.
+func SetDefault(l *zap.Logger) {
+	defaultLogger.Store(l)
 }
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey is the gRPC metadata key carrying the request ID.
+const RequestIDMetadataKey = "x-request-id"
+
+// NewMiddleware stores a logger carrying the request ID, set by the requestid
+// middleware, and the trace IDs of the request in the request's user context,
+// and logs every request once it has been handled. It must be registered after
+// the requestid and tracing middleware.
+func NewMiddleware(base *zap.Logger) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		start := time.Now()
+		requestID := requestid.FromContext(ctx)
+		l := base.With(requestFields(ctx.UserContext(), requestID)...)
+
+		userCtx := WithRequestID(ctx.UserContext(), requestID)
+		ctx.SetUserContext(WithContext(userCtx, l))
+
+		// Errors are handled here rather than left to the app, so that the
+		// status written by the error handler is the one logged.
+		if err := ctx.Next(); err != nil {
+			if handlerErr := ctx.App().ErrorHandler(ctx, err); handlerErr != nil {
+				_ = ctx.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		statusCode := ctx.Response().StatusCode()
+		fields := []zap.Field{
+			zap.String("method", ctx.Method()),
+			zap.String("path", ctx.Path()),
+			zap.Int("status", statusCode),
+			zap.Duration("latency", time.Since(start)),
+		}
+		if statusCode >= fiber.StatusInternalServerError {
+			l.Error("Request failed", fields...)
+		} else {
+			l.Info("Request completed", fields...)
+		}
+		return nil
+	}
+}
+
+// UnaryServerInterceptor is the gRPC counterpart of NewMiddleware. The request
+// ID is taken from the x-request-id metadata key, or generated, and echoed
+// back in a header.
+func UnaryServerInterceptor(base *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		requestID := firstValue(md, RequestIDMetadataKey)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+
+		l := base.With(requestFields(ctx, requestID)...)
+		ctx = WithContext(WithRequestID(ctx, requestID), l)
+
+		res, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+		if isServerError(code) {
+			l.Error("Request failed", append(fields, zap.Error(err))...)
+		} else {
+			l.Info("Request completed", fields...)
+		}
+		return res, err
+	}
+}
+
+// isServerError reports whether a status code reports a failure of the server
+// rather than of the request.
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestFields are the fields attached to every entry logged while serving
+// a request. The trace IDs are those of the span in ctx.
+func requestFields(ctx context.Context, requestID string) []zap.Field {
+	fields := []zap.Field{zap.String("request_id", requestID)}
+
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields, zap.String("trace_id", spanCtx.TraceID().String()), zap.String("span_id", spanCtx.SpanID().String()))
+	}
+	return fields
+}
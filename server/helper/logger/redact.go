@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces every secret removed from a log entry or error message.
+const Redacted = "[REDACTED]"
+
+// secretKeys are the words marking a field, or a key=value pair inside a
+// string, as secret.
+var secretKeys = []string{"password", "passwd", "secret", "token", "authorization", "api_key", "apikey", "private_key", "credential"}
+
+var (
+	dsnPasswordPattern = regexp.MustCompile(`(://[^:/?#@\s]+:)[^@/\s]+@`)
+	// bearerPattern matches the token68 credentials of a bearer scheme. Real
+	// tokens are long, which keeps prose such as "missing bearer token" intact.
+	bearerPattern   = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/-]{16,}=*`)
+	keyValuePattern = regexp.MustCompile(`(?i)([\w.-]*(?:` + strings.Join(secretKeys, "|") + `)[\w.-]*"?\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s"&,;]+)`)
+)
+
+// minSecretLength is the length below which a registered secret is not masked
+// wherever it appears. Short values such as "password" also occur in plain
+// words; they are masked only where a pattern places them, such as after a
+// secret key or in a DSN.
+const minSecretLength = 12
+
+var secrets = struct {
+	sync.RWMutex
+	values map[string]struct{}
+}{values: make(map[string]struct{})}
+
+// RegisterSecret marks value as secret, so that it is masked wherever it
+// appears in a log entry. Values shorter than minSecretLength are ignored.
+func RegisterSecret(value string) {
+	if len(value) < minSecretLength {
+		return
+	}
+	secrets.Lock()
+	defer secrets.Unlock()
+	secrets.values[value] = struct{}{}
+}
+
+// UnregisterSecret stops masking value, once it has been rotated out and is
+// no longer valid.
+func UnregisterSecret(value string) {
+	secrets.Lock()
+	defer secrets.Unlock()
+	delete(secrets.values, value)
+}
+
+// IsSecretKey reports whether a field or configuration key names a secret.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secretKey := range secretKeys {
+		if strings.Contains(key, secretKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact masks the registered secrets, DSN passwords, bearer tokens and the
+// values of secret key=value pairs in s.
+func Redact(s string) string {
+	secrets.RLock()
+	for value := range secrets.values {
+		s = strings.ReplaceAll(s, value, Redacted)
+	}
+	secrets.RUnlock()
+
+	s = dsnPasswordPattern.ReplaceAllString(s, "${1}"+Redacted+"@")
+	s = bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+	return keyValuePattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// redactCore redacts the message and fields of every entry before handing it
+// to the wrapped core.
+type redactCore struct {
+	zapcore.Core
+}
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redactFields(fields))}
+}
+
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = Redact(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = redactField(field)
+	}
+	return redacted
+}
+
+func redactField(field zapcore.Field) zapcore.Field {
+	if IsSecretKey(field.Key) {
+		return zap.String(field.Key, Redacted)
+	}
+
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = Redact(field.String)
+	case zapcore.ByteStringType:
+		if value, ok := field.Interface.([]byte); ok {
+			return zap.ByteString(field.Key, []byte(Redact(string(value))))
+		}
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return zap.String(field.Key, Redact(err.Error()))
+		}
+	case zapcore.StringerType:
+		if stringer, ok := field.Interface.(fmt.Stringer); ok {
+			return zap.String(field.Key, Redact(stringer.String()))
+		}
+	case zapcore.ReflectType:
+		return zap.Any(field.Key, redactValue(field.Interface))
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		encoder := zapcore.NewMapObjectEncoder()
+		field.AddTo(encoder)
+		return zap.Any(field.Key, redactValue(encoder.Fields[field.Key]))
+	}
+	return field
+}
+
+// redactValue masks the secrets in a value logged by reflection or gathered
+// from a marshaler. Other values are turned into their JSON form, so that the
+// fields of structs are masked by their names as map keys are.
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case nil, bool, float64, json.Number:
+		return value
+	case string:
+		return Redact(value)
+	case map[string]any:
+		for key, v := range value {
+			if IsSecretKey(key) {
+				value[key] = Redacted
+			} else {
+				value[key] = redactValue(v)
+			}
+		}
+		return value
+	case []any:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+		return value
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return value
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return Redact(fmt.Sprint(value))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return Redact(string(raw))
+	}
+	return redactValue(decoded)
+}
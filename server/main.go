@@ -1,62 +1,463 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
+	"github.com/daffaromero/matesite/server/auth"
 	"github.com/daffaromero/matesite/server/config"
 	"github.com/daffaromero/matesite/server/controller"
+	"github.com/daffaromero/matesite/server/health"
 	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/metrics"
+	"github.com/daffaromero/matesite/server/migrations"
+	api "github.com/daffaromero/matesite/server/protobuf"
 	"github.com/daffaromero/matesite/server/repository"
 	"github.com/daffaromero/matesite/server/repository/query"
 	"github.com/daffaromero/matesite/server/service"
+	"github.com/daffaromero/matesite/server/tracing"
+	"github.com/daffaromero/matesite/server/vault"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
-var logs = logger.New("main")
+// webServer serves the issue API over HTTP and gRPC, and the metrics, until
+// ctx is done or a listener fails, and then shuts down gracefully. The
+// configuration is reloaded on SIGHUP.
+func webServer(ctx context.Context, reloader *config.Reloader, logs *zap.Logger, checker *health.Checker) error {
+	cfg := reloader.Current()
 
-func webServer() error {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: controller.ErrorHandler,
+	})
+	app.Use(metrics.NewMiddleware())
 	app.Use(requestid.New())
+	app.Use(tracing.NewMiddleware())
+	app.Use(logger.NewMiddleware(logs))
+
+	vaultClient, err := vault.Shared()
+	if err != nil {
+		logs.Error("Failed to connect to Vault", zap.Error(err))
+		return err
+	}
+	var dbCredentials *vault.DatabaseCredentials
+	if cfg.Database.VaultRole != "" {
+		if vaultClient == nil {
+			err := errors.New("DB_VAULT_ROLE is set, but Vault is not configured, set VAULT_ADDR")
+			logs.Error("Failed to lease database credentials", zap.Error(err))
+			return err
+		}
+		if dbCredentials, err = vaultClient.DatabaseCredentials(ctx, cfg.Database.VaultMount, cfg.Database.VaultRole); err != nil {
+			logs.Error("Failed to lease database credentials", zap.Error(err))
+			return err
+		}
+		// Deferred before the pool is closed, so that it runs after.
+		defer func() {
+			revokeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := dbCredentials.Revoke(revokeCtx); err != nil {
+				logs.Warn("Failed to revoke database credentials", zap.Error(err))
+			}
+		}()
+	}
 
-	serverConfig := config.NewServerConfig()
-	dbConfig := config.NewPostgresDatabase()
-	store := repository.NewStore(dbConfig)
+	dbConfig, err := config.NewPostgresDatabase(cfg.Database, dbCredentials)
+	if err != nil {
+		return err
+	}
+	// Closed last, after the servers have drained and the workers stopped, or
+	// on any failure to start.
+	defer dbConfig.Close()
+	store := repository.NewStore(dbConfig, cfg.Database.Timeout)
+	if err := metrics.RegisterPool(dbConfig); err != nil {
+		logs.Error("Failed to register database pool metrics", zap.Error(err))
+		return err
+	}
 	validate := validator.New()
 
+	userQuery := query.NewUserQuery(dbConfig)
+	userRepo := repository.NewUserRepository(store, userQuery)
+	projectQuery := query.NewProjectQuery(dbConfig)
+	projectRepo := repository.NewProjectRepository(store, projectQuery)
+	policy := service.NewPolicy(userRepo, projectRepo)
+
+	userService := service.NewUserService(userRepo, policy)
+	userController := controller.NewUserController(validate, userService, cfg.Endpoints)
+
+	projectService := service.NewProjectService(projectRepo, policy)
+	projectController := controller.NewProjectController(validate, projectService, cfg.Endpoints)
+
 	issueQuery := query.NewIssueQuery(dbConfig)
-	issueRepo := repository.NewIssueRepository(store, issueQuery)
-	issueService := service.NewIssueService(issueRepo, logs)
-	issueController := controller.NewIssueController(validate, issueService)
+	issueEventQuery := query.NewIssueEventQuery(dbConfig)
+	issueRepo := repository.NewIssueRepository(store, issueQuery, issueEventQuery)
+	issueService := service.NewIssueService(issueRepo, policy)
+	issueController := controller.NewIssueController(validate, issueService, cfg.Endpoints)
+	trashPurger := service.NewTrashPurger(issueRepo, cfg.Trash)
 
-	logs.Log(fmt.Sprintf("Starting HTTP issue server on %s", serverConfig.HTTP))
-	app.Use(cors.New())
-	issueController.Route(app)
+	labelQuery := query.NewLabelQuery(dbConfig)
+	labelRepo := repository.NewLabelRepository(store, labelQuery)
+	labelService := service.NewLabelService(labelRepo, policy)
+	labelController := controller.NewLabelController(validate, labelService, cfg.Endpoints)
+
+	commentQuery := query.NewCommentQuery(dbConfig)
+	commentRepo := repository.NewCommentRepository(store, commentQuery)
+	commentService := service.NewCommentService(commentRepo, policy)
+	commentController := controller.NewCommentController(validate, commentService, cfg.Endpoints)
+
+	healthController := controller.NewHealthController(checker)
+
+	configService := service.NewConfigService(reloader, policy)
+	configController := controller.NewConfigController(configService, cfg.Endpoints)
+
+	issueGrpcController := controller.NewIssueGrpcController(issueService, labelService, userService, commentService, projectService)
+
+	authenticator, err := auth.NewJWTAuthenticator(cfg.Auth)
+	if err != nil {
+		logs.Error("Failed to configure authentication", zap.Error(err))
+		return err
+	}
+
+	grpcServer := grpc.NewServer(grpc.StatsHandler(tracing.ServerHandler()), grpc.ChainUnaryInterceptor(
+		metrics.UnaryServerInterceptor(),
+		tracing.UnaryServerInterceptor(),
+		logger.UnaryServerInterceptor(logs),
+		controller.UnaryErrorInterceptor(cfg.Server.Name),
+		auth.UnaryServerInterceptor(authenticator, healthpb.Health_ServiceDesc.ServiceName),
+	))
+	api.RegisterIssuesServiceServer(grpcServer, issueGrpcController)
+	healthpb.RegisterHealthServer(grpcServer, checker.GRPCServer())
 
-	err := app.Listen(serverConfig.HTTP, fiber.ListenConfig{
-		DisableStartupMessage: true,
+	latestMigration, err := migrations.Latest()
+	if err != nil {
+		logs.Error("Failed to read migrations", zap.Error(err))
+		return err
+	}
+	checker.Add("database", health.Database(dbConfig))
+	checker.Add("migrations", health.Migrations(dbConfig, latestMigration))
+	if vaultClient != nil {
+		checker.Add("vault", vaultClient.Ping)
+	}
+
+	// The CORS middleware is rebuilt when the allowed origins are reloaded,
+	// and swapped in for requests that start afterwards.
+	var corsHandler atomic.Pointer[fiber.Handler]
+	setCORS := func(origins []string) {
+		handler := newCORS(origins)
+		corsHandler.Store(&handler)
+	}
+	setCORS(cfg.Auth.CORSAllowOrigins)
+	app.Use(func(ctx fiber.Ctx) error {
+		return (*corsHandler.Load())(ctx)
 	})
+
+	reloader.Subscribe(func(cfg *config.Config) {
+		if err := logger.SetLevel(cfg.Log.Level); err != nil {
+			logs.Error("Failed to set log level", zap.Error(err))
+		}
+		setCORS(cfg.Auth.CORSAllowOrigins)
+		trashPurger.Reconfigure(cfg.Trash)
+	})
+
+	healthController.Route(app)
+	app.Use(auth.NewMiddleware(authenticator))
+	projectController.Route(app)
+	issueController.Route(app)
+	labelController.Route(app)
+	userController.Route(app)
+	commentController.Route(app)
+	configController.Route(app)
+
+	// Every listener is bound before anything is served, so that a port in
+	// use fails startup rather than leaving the server half up.
+	httpListener, err := net.Listen("tcp", cfg.Server.HTTP())
+	if err != nil {
+		logs.Error("Failed to listen for HTTP issue server", zap.Error(err))
+		return err
+	}
+	grpcListener, err := net.Listen("tcp", cfg.Server.GRPC())
 	if err != nil {
-		logs.Error("Failed to start issue server")
+		httpListener.Close()
+		logs.Error("Failed to listen for gRPC issue server", zap.Error(err))
 		return err
 	}
+	var metricsServer *http.Server
+	var metricsListener net.Listener
+	if metricsAddr := cfg.MetricsAddress(); metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.Metrics.Path, metrics.Handler())
+		metricsServer = &http.Server{Addr: metricsAddr, Handler: mux}
+		if metricsListener, err = net.Listen("tcp", metricsAddr); err != nil {
+			httpListener.Close()
+			grpcListener.Close()
+			logs.Error("Failed to listen for metrics server", zap.Error(err))
+			return err
+		}
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	workers.Add(3)
+	go func() {
+		defer workers.Done()
+		trashPurger.Run(logger.WithContext(workersCtx, logs.Named("trash_purger")))
+	}()
+	go func() {
+		defer workers.Done()
+		checker.Watch(logger.WithContext(workersCtx, logs.Named("health")), cfg.Health.Interval)
+	}()
+	go func() {
+		defer workers.Done()
+		reloadOnHangup(logger.WithContext(workersCtx, logs.Named("config")), reloader)
+	}()
+	if vaultClient != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			vaultClient.Run(logger.WithContext(workersCtx, logs.Named("vault")))
+		}()
+	}
+	if dbCredentials != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			dbCredentials.Run(logger.WithContext(workersCtx, logs.Named("database_credentials")))
+		}()
+	}
+
+	serveErrs := make(chan error, 3)
+	go func() {
+		if err := app.Listener(httpListener, fiber.ListenConfig{DisableStartupMessage: true}); err != nil {
+			serveErrs <- fmt.Errorf("HTTP issue server stopped: %w", err)
+		}
+	}()
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			serveErrs <- fmt.Errorf("gRPC issue server stopped: %w", err)
+		}
+	}()
+	if metricsServer != nil {
+		go func() {
+			if err := metricsServer.Serve(metricsListener); !errors.Is(err, http.ErrServerClosed) {
+				serveErrs <- fmt.Errorf("metrics server stopped: %w", err)
+			}
+		}()
+	}
+	logs.Info("Issue server started",
+		zap.String("http_addr", cfg.Server.HTTP()),
+		zap.String("grpc_addr", cfg.Server.GRPC()),
+		zap.String("metrics_addr", cfg.MetricsAddress()),
+	)
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		logs.Info("Received signal, shutting down gracefully", zap.Duration("timeout", cfg.Shutdown.Timeout))
+	case serveErr = <-serveErrs:
+		logs.Error("Issue server failed, shutting down", zap.Error(serveErr))
+	}
+
+	// Report not ready first, so that load balancers stop routing here, then
+	// stop accepting and let in-flight requests finish within the timeout.
+	checker.Drain()
+	time.Sleep(cfg.Shutdown.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+	defer cancel()
+
+	var shutdown sync.WaitGroup
+	shutdownErrs := make(chan error, 3)
+	shutdown.Add(2)
+	go func() {
+		defer shutdown.Done()
+		if err := app.ShutdownWithContext(shutdownCtx); err != nil {
+			shutdownErrs <- fmt.Errorf("failed to drain HTTP issue server: %w", err)
+		}
+	}()
+	go func() {
+		defer shutdown.Done()
+		if err := stopGRPCServer(shutdownCtx, grpcServer); err != nil {
+			shutdownErrs <- fmt.Errorf("failed to drain gRPC issue server: %w", err)
+		}
+	}()
+	if metricsServer != nil {
+		shutdown.Add(1)
+		go func() {
+			defer shutdown.Done()
+			if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+				shutdownErrs <- fmt.Errorf("failed to stop metrics server: %w", err)
+			}
+		}()
+	}
+	shutdown.Wait()
+	close(shutdownErrs)
+
+	// Requests are done with the pool, and the workers are stopped before it
+	// is closed, as a purge in progress still holds a connection.
+	stopWorkers()
+	workers.Wait()
+
+	failures := []error{serveErr}
+	for err := range shutdownErrs {
+		failures = append(failures, err)
+	}
+	if err := errors.Join(failures...); err != nil {
+		return err
+	}
+	logs.Info("Issue server stopped")
 	return nil
 }
 
+// newCORS returns the CORS middleware allowing origins, or every origin when
+// there are none. The origins must have passed config validation.
+func newCORS(origins []string) fiber.Handler {
+	corsConfig := cors.Config{
+		AllowHeaders:  []string{fiber.HeaderOrigin, fiber.HeaderContentType, fiber.HeaderAccept, fiber.HeaderAuthorization, fiber.HeaderIfMatch, fiber.HeaderIfNoneMatch},
+		ExposeHeaders: []string{fiber.HeaderETag, tracing.TraceIDHeader},
+	}
+	if len(origins) > 0 {
+		corsConfig.AllowOrigins = origins
+	}
+	return cors.New(corsConfig)
+}
+
+// reloadOnHangup reloads the configuration on every SIGHUP until ctx is done.
+// Reload logs the outcome.
+func reloadOnHangup(ctx context.Context, reloader *config.Reloader) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			_, _ = reloader.Reload(ctx)
+		}
+	}
+}
+
+// stopGRPCServer stops server gracefully, or forcibly once ctx is done.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
+
+// Exit codes of the process.
+const (
+	exitOK = 0
+	// exitFailure reports a server that failed to start or stopped on an
+	// error rather than a signal.
+	exitFailure = 1
+	// exitShutdownTimeout reports a shutdown that cut off requests still in
+	// flight when the timeout ran out.
+	exitShutdownTimeout = 2
+	// exitUsage reports an unknown command or flag.
+	exitUsage = 64
+)
+
+const usage = `Usage:
+  matesite [--config FILE]                          serve the issue API
+  matesite [--config FILE] config print [--redact]  print the configuration and where each value comes from
+`
+
 func main() {
-	if err := webServer(); err != nil {
-		logs.Error(err)
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("matesite", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	configPath := flags.String("config", "", "YAML or TOML configuration file, $"+config.ConfigFileEnv+" by default")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
 	}
 
-	logs.Log("Issue server started")
-	sigchan := make(chan os.Signal, 1)
-	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
+	switch command := flags.Arg(0); command {
+	case "":
+		return serve(*configPath)
+	case "config":
+		return configCommand(flags.Args()[1:], *configPath)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s", command, usage)
+		return exitUsage
+	}
+}
+
+func serve(configPath string) int {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
 
-	sig := <-sigchan
-	logs.Log(fmt.Sprintf("Received signal: %s. Shutting down gracefully...", sig))
+	logs, err := logger.New(logger.Config{
+		Level:   cfg.Log.Level,
+		Format:  cfg.Log.Format,
+		Service: cfg.Server.Name,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	logger.SetDefault(logs)
+	defer func() { _ = logs.Sync() }()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		File:        cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		Service:     cfg.Server.Name,
+	})
+	if err != nil {
+		logs.Error("Failed to set up tracing", zap.Error(err))
+		return exitFailure
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logs.Error("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	checker := health.NewChecker(api.IssuesService_ServiceDesc.ServiceName)
+	if err := webServer(ctx, config.NewReloader(configPath, cfg), logs, checker); err != nil {
+		logs.Error("Issue server failed", zap.Error(err))
+		if errors.Is(err, context.DeadlineExceeded) {
+			return exitShutdownTimeout
+		}
+		return exitFailure
+	}
+	return exitOK
 }
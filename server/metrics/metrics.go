@@ -0,0 +1,92 @@
+// Package metrics holds the Prometheus metrics of the service and the
+// middleware recording them.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric of the service, along with the Go runtime and
+// process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// Transactions counts the transactions run by repository.Store.WithTx by
+	// result, commit or rollback.
+	Transactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transactions_total",
+		Help: "Database transactions by result.",
+	}, []string{"result"})
+	// DBCredentialRenewals and DBCredentialRotations count the renewals of
+	// the database credentials leased from Vault, and their replacements by
+	// new credentials, by result, success or failure.
+	DBCredentialRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_credential_renewals_total",
+		Help: "Renewals of the database credentials lease by result.",
+	}, []string{"result"})
+	DBCredentialRotations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_credential_rotations_total",
+		Help: "Rotations of the database credentials by result.",
+	}, []string{"result"})
+	DBCredentialExpiry = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "db_credential_lease_expiry_timestamp_seconds",
+		Help: "When the lease of the database credentials in use runs out, unless renewed.",
+	})
+
+	IssuesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "issues_created_total",
+		Help: "Issues created.",
+	})
+	IssuesClosed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "issues_closed_total",
+		Help: "Issues transitioned to closed.",
+	})
+	IssueTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "issue_transitions_total",
+		Help: "Issue status transitions by source and target status.",
+	}, []string{"from", "to"})
+	IssuesDeleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "issues_deleted_total",
+		Help: "Issues moved to the trash.",
+	})
+	IssuesRestored = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "issues_restored_total",
+		Help: "Issues restored from the trash.",
+	})
+	// IssuesPurged counts permanently deleted issues by trigger, manual for
+	// purges requested by a user and retention for the trash purger.
+	IssuesPurged = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "issues_purged_total",
+		Help: "Issues permanently deleted, by trigger.",
+	}, []string{"trigger"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		Transactions,
+		DBCredentialRenewals,
+		DBCredentialRotations,
+		DBCredentialExpiry,
+		IssuesCreated,
+		IssuesClosed,
+		IssueTransitions,
+		IssuesDeleted,
+		IssuesRestored,
+		IssuesPurged,
+		httpRequests,
+		httpRequestDuration,
+		grpcRequests,
+		grpcRequestDuration,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
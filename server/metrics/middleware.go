@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method, route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "gRPC request latency by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// NewMiddleware records the count and latency of HTTP requests. Routes are
+// labelled with their pattern, not the request path, to bound cardinality. It
+// must be registered before the middleware handling errors, so that the
+// status recorded is the one written.
+func NewMiddleware() fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		start := time.Now()
+		err := ctx.Next()
+
+		statusCode := ctx.Response().StatusCode()
+		if err != nil {
+			statusCode = fiber.StatusInternalServerError
+		}
+		labels := prometheus.Labels{
+			"method": ctx.Method(),
+			"route":  ctx.Route().Path,
+			"status": strconv.Itoa(statusCode),
+		}
+		httpRequests.With(labels).Inc()
+		httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// UnaryServerInterceptor is the gRPC counterpart of NewMiddleware.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+
+		labels := prometheus.Labels{
+			"method": info.FullMethod,
+			"code":   status.Code(err).String(),
+		}
+		grpcRequests.With(labels).Inc()
+		grpcRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		return res, err
+	}
+}
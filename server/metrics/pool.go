@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector exports the statistics of a connection pool, read when the
+// metrics are scraped.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns       *prometheus.Desc
+	idleConns           *prometheus.Desc
+	constructingConns   *prometheus.Desc
+	totalConns          *prometheus.Desc
+	maxConns            *prometheus.Desc
+	acquires            *prometheus.Desc
+	emptyAcquires       *prometheus.Desc
+	canceledAcquires    *prometheus.Desc
+	acquireDuration     *prometheus.Desc
+	newConns            *prometheus.Desc
+	maxLifetimeDestroys *prometheus.Desc
+	maxIdleDestroys     *prometheus.Desc
+}
+
+// RegisterPool exports the statistics of pool.
+func RegisterPool(pool *pgxpool.Pool) error {
+	return Registry.Register(newPoolCollector(pool))
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	return &poolCollector{
+		pool:                pool,
+		acquiredConns:       prometheus.NewDesc("db_pool_acquired_conns", "Connections currently acquired from the pool.", nil, nil),
+		idleConns:           prometheus.NewDesc("db_pool_idle_conns", "Idle connections in the pool.", nil, nil),
+		constructingConns:   prometheus.NewDesc("db_pool_constructing_conns", "Connections being established.", nil, nil),
+		totalConns:          prometheus.NewDesc("db_pool_total_conns", "Connections in the pool.", nil, nil),
+		maxConns:            prometheus.NewDesc("db_pool_max_conns", "Maximum size of the pool.", nil, nil),
+		acquires:            prometheus.NewDesc("db_pool_acquires_total", "Connections acquired from the pool.", nil, nil),
+		emptyAcquires:       prometheus.NewDesc("db_pool_empty_acquires_total", "Acquires that waited for a connection because the pool was empty.", nil, nil),
+		canceledAcquires:    prometheus.NewDesc("db_pool_canceled_acquires_total", "Acquires canceled by their context.", nil, nil),
+		acquireDuration:     prometheus.NewDesc("db_pool_acquire_duration_seconds_total", "Time spent acquiring connections.", nil, nil),
+		newConns:            prometheus.NewDesc("db_pool_new_conns_total", "Connections opened.", nil, nil),
+		maxLifetimeDestroys: prometheus.NewDesc("db_pool_max_lifetime_destroys_total", "Connections closed for exceeding their maximum lifetime.", nil, nil),
+		maxIdleDestroys:     prometheus.NewDesc("db_pool_max_idle_destroys_total", "Connections closed for exceeding their maximum idle time.", nil, nil),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.newConns, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeDestroys, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleDestroys, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS "issues_created_at_id_idx" ON issues ("created_at" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "issues_search_vector_idx";
+
+ALTER TABLE issues DROP COLUMN IF EXISTS "search_vector";
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE issues ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('english', coalesce("title", '')), 'A') ||
+  setweight(to_tsvector('english', coalesce("description", '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "issues_search_vector_idx" ON issues USING GIN ("search_vector");
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS issue_status_transitions;
+
+DROP INDEX IF EXISTS "issues_status_idx";
+
+ALTER TABLE issues
+  DROP COLUMN IF EXISTS "status_changed_at",
+  DROP COLUMN IF EXISTS "status_changed_by",
+  DROP COLUMN IF EXISTS "status";
\ No newline at end of file
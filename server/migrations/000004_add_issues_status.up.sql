@@ -0,0 +1,18 @@
+ALTER TABLE issues
+  ADD COLUMN "status" VARCHAR(32) NOT NULL DEFAULT 'open'
+    CHECK ("status" IN ('open', 'in_progress', 'blocked', 'resolved', 'closed')),
+  ADD COLUMN "status_changed_by" VARCHAR(255) DEFAULT NULL,
+  ADD COLUMN "status_changed_at" TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS "issues_status_idx" ON issues ("status") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE issue_status_transitions (
+  "id" BIGSERIAL PRIMARY KEY,
+  "issue_id" uuid NOT NULL REFERENCES issues ("id") ON DELETE CASCADE,
+  "from_status" VARCHAR(32) NOT NULL,
+  "to_status" VARCHAR(32) NOT NULL,
+  "changed_by" VARCHAR(255) DEFAULT NULL,
+  "changed_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "issue_status_transitions_issue_id_idx" ON issue_status_transitions ("issue_id", "changed_at");
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE labels (
+  "id" uuid PRIMARY KEY,
+  "name" VARCHAR(64) NOT NULL,
+  "color" VARCHAR(7) NOT NULL DEFAULT '#cccccc' CHECK ("color" ~ '^#[0-9a-fA-F]{6}$'),
+  "description" TEXT DEFAULT '',
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "labels_name_key" ON labels (lower("name"));
+
+CREATE TABLE issue_labels (
+  "issue_id" uuid NOT NULL REFERENCES issues ("id") ON DELETE CASCADE,
+  "label_id" uuid NOT NULL REFERENCES labels ("id") ON DELETE CASCADE,
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("issue_id", "label_id")
+);
+
+CREATE INDEX IF NOT EXISTS "issue_labels_label_id_idx" ON issue_labels ("label_id");
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS issue_assignees;
+
+DROP INDEX IF EXISTS "issues_reporter_id_idx";
+
+ALTER TABLE issues DROP COLUMN IF EXISTS "reporter_id";
+
+DROP TABLE IF EXISTS users;
\ No newline at end of file
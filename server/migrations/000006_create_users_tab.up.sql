@@ -0,0 +1,24 @@
+CREATE TABLE users (
+  "id" uuid PRIMARY KEY,
+  "username" VARCHAR(64) NOT NULL,
+  "display_name" VARCHAR(255) DEFAULT '',
+  "email" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "users_username_key" ON users (lower("username"));
+CREATE UNIQUE INDEX IF NOT EXISTS "users_email_key" ON users (lower("email"));
+
+ALTER TABLE issues ADD COLUMN "reporter_id" uuid DEFAULT NULL REFERENCES users ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "issues_reporter_id_idx" ON issues ("reporter_id") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE issue_assignees (
+  "issue_id" uuid NOT NULL REFERENCES issues ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES users ("id") ON DELETE CASCADE,
+  "assigned_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("issue_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS "issue_assignees_user_id_idx" ON issue_assignees ("user_id");
\ No newline at end of file
@@ -0,0 +1,22 @@
+CREATE TABLE comments (
+  "id" uuid PRIMARY KEY,
+  "issue_id" uuid NOT NULL REFERENCES issues ("id") ON DELETE CASCADE,
+  "author_id" uuid NOT NULL REFERENCES users ("id"),
+  "parent_id" uuid DEFAULT NULL REFERENCES comments ("id") ON DELETE CASCADE,
+  "body" TEXT NOT NULL,
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  "deleted_at" TIMESTAMPTZ DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "comments_issue_id_created_at_idx" ON comments ("issue_id", "created_at", "id") WHERE "deleted_at" IS NULL;
+
+CREATE TABLE comment_revisions (
+  "id" BIGSERIAL PRIMARY KEY,
+  "comment_id" uuid NOT NULL REFERENCES comments ("id") ON DELETE CASCADE,
+  "body" TEXT NOT NULL,
+  "edited_by" uuid DEFAULT NULL REFERENCES users ("id") ON DELETE SET NULL,
+  "edited_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "comment_revisions_comment_id_idx" ON comment_revisions ("comment_id", "edited_at");
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+  ADD COLUMN "role" VARCHAR(32) NOT NULL DEFAULT 'viewer'
+    CHECK ("role" IN ('viewer', 'member', 'maintainer'));
\ No newline at end of file
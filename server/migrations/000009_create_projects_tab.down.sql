@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "issues_project_id_created_at_id_idx";
+
+CREATE INDEX IF NOT EXISTS "issues_created_at_id_idx" ON issues ("created_at" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
+
+ALTER TABLE issues DROP COLUMN IF EXISTS "project_id";
+
+DROP TABLE IF EXISTS project_members;
+
+DROP TABLE IF EXISTS projects;
\ No newline at end of file
@@ -0,0 +1,36 @@
+CREATE TABLE projects (
+  "id" uuid PRIMARY KEY,
+  "name" VARCHAR(128) NOT NULL,
+  "description" TEXT DEFAULT '',
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "projects_name_key" ON projects (lower("name"));
+
+CREATE TABLE project_members (
+  "project_id" uuid NOT NULL REFERENCES projects ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES users ("id") ON DELETE CASCADE,
+  "role" VARCHAR(32) NOT NULL DEFAULT 'viewer'
+    CHECK ("role" IN ('viewer', 'member', 'maintainer')),
+  "created_at" TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("project_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS "project_members_user_id_idx" ON project_members ("user_id");
+
+-- Existing issues and users move into a default project so nothing is lost.
+INSERT INTO projects ("id", "name", "description") VALUES ('00000000-0000-0000-0000-000000000001', 'Default', 'Issues created before projects existed.');
+
+INSERT INTO project_members ("project_id", "user_id", "role")
+  SELECT '00000000-0000-0000-0000-000000000001', "id", "role" FROM users;
+
+ALTER TABLE issues ADD COLUMN "project_id" uuid REFERENCES projects ("id") ON DELETE RESTRICT;
+
+UPDATE issues SET "project_id" = '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE issues ALTER COLUMN "project_id" SET NOT NULL;
+
+DROP INDEX IF EXISTS "issues_created_at_id_idx";
+
+CREATE INDEX IF NOT EXISTS "issues_project_id_created_at_id_idx" ON issues ("project_id", "created_at" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Events deliberately have no foreign key to issues so the audit trail
+-- outlives the issues it describes.
+CREATE TABLE issue_events (
+  "id" BIGSERIAL PRIMARY KEY,
+  "issue_id" uuid NOT NULL,
+  "project_id" uuid NOT NULL,
+  "actor_id" uuid DEFAULT NULL,
+  "operation" VARCHAR(32) NOT NULL,
+  "changes" JSONB NOT NULL DEFAULT '[]',
+  "occurred_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "issue_events_issue_id_occurred_at_idx" ON issue_events ("issue_id", "occurred_at", "id");
\ No newline at end of file
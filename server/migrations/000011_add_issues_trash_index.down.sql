@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "issues_deleted_at_idx";
+DROP INDEX IF EXISTS "issues_project_id_deleted_at_id_idx";
\ No newline at end of file
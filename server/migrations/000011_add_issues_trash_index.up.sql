@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS "issues_project_id_deleted_at_id_idx" ON issues ("project_id", "deleted_at" DESC, "id" DESC) WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "issues_deleted_at_idx" ON issues ("deleted_at") WHERE "deleted_at" IS NOT NULL;
\ No newline at end of file
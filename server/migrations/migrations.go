@@ -0,0 +1,33 @@
+// Package migrations embeds the SQL migrations, named in the golang-migrate
+// convention NNNNNN_name.up.sql and NNNNNN_name.down.sql.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Latest returns the version of the newest migration.
+func Latest() (uint64, error) {
+	names, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration name %q", name)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
@@ -27,6 +27,38 @@ type IssuesServiceClient interface {
 	ListIssues(ctx context.Context, in *ListIssuesRequest, opts ...grpc.CallOption) (*ListIssuesResponse, error)
 	UpdateIssue(ctx context.Context, in *UpdateIssueRequest, opts ...grpc.CallOption) (*UpdateIssueResponse, error)
 	DeleteIssue(ctx context.Context, in *DeleteIssueRequest, opts ...grpc.CallOption) (*DeleteIssueResponse, error)
+	ListDeletedIssues(ctx context.Context, in *ListDeletedIssuesRequest, opts ...grpc.CallOption) (*ListDeletedIssuesResponse, error)
+	RestoreIssue(ctx context.Context, in *RestoreIssueRequest, opts ...grpc.CallOption) (*RestoreIssueResponse, error)
+	PurgeIssue(ctx context.Context, in *PurgeIssueRequest, opts ...grpc.CallOption) (*PurgeIssueResponse, error)
+	TransitionIssue(ctx context.Context, in *TransitionIssueRequest, opts ...grpc.CallOption) (*TransitionIssueResponse, error)
+	ListIssueEvents(ctx context.Context, in *ListIssueEventsRequest, opts ...grpc.CallOption) (*ListIssueEventsResponse, error)
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
+	ListProjectMembers(ctx context.Context, in *ListProjectMembersRequest, opts ...grpc.CallOption) (*ListProjectMembersResponse, error)
+	SetProjectMember(ctx context.Context, in *SetProjectMemberRequest, opts ...grpc.CallOption) (*SetProjectMemberResponse, error)
+	RemoveProjectMember(ctx context.Context, in *RemoveProjectMemberRequest, opts ...grpc.CallOption) (*RemoveProjectMemberResponse, error)
+	CreateLabel(ctx context.Context, in *CreateLabelRequest, opts ...grpc.CallOption) (*CreateLabelResponse, error)
+	GetLabel(ctx context.Context, in *GetLabelRequest, opts ...grpc.CallOption) (*GetLabelResponse, error)
+	ListLabels(ctx context.Context, in *ListLabelsRequest, opts ...grpc.CallOption) (*ListLabelsResponse, error)
+	UpdateLabel(ctx context.Context, in *UpdateLabelRequest, opts ...grpc.CallOption) (*UpdateLabelResponse, error)
+	DeleteLabel(ctx context.Context, in *DeleteLabelRequest, opts ...grpc.CallOption) (*DeleteLabelResponse, error)
+	AddIssueLabel(ctx context.Context, in *AddIssueLabelRequest, opts ...grpc.CallOption) (*IssueLabelsResponse, error)
+	RemoveIssueLabel(ctx context.Context, in *RemoveIssueLabelRequest, opts ...grpc.CallOption) (*IssueLabelsResponse, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*SetUserRoleResponse, error)
+	AssignIssue(ctx context.Context, in *AssignIssueRequest, opts ...grpc.CallOption) (*IssueAssigneesResponse, error)
+	UnassignIssue(ctx context.Context, in *UnassignIssueRequest, opts ...grpc.CallOption) (*IssueAssigneesResponse, error)
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*GetCommentResponse, error)
+	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	ListCommentRevisions(ctx context.Context, in *ListCommentRevisionsRequest, opts ...grpc.CallOption) (*ListCommentRevisionsResponse, error)
 }
 
 type issuesServiceClient struct {
@@ -82,6 +114,294 @@ func (c *issuesServiceClient) DeleteIssue(ctx context.Context, in *DeleteIssueRe
 	return out, nil
 }
 
+func (c *issuesServiceClient) ListDeletedIssues(ctx context.Context, in *ListDeletedIssuesRequest, opts ...grpc.CallOption) (*ListDeletedIssuesResponse, error) {
+	out := new(ListDeletedIssuesResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListDeletedIssues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) RestoreIssue(ctx context.Context, in *RestoreIssueRequest, opts ...grpc.CallOption) (*RestoreIssueResponse, error) {
+	out := new(RestoreIssueResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/RestoreIssue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) PurgeIssue(ctx context.Context, in *PurgeIssueRequest, opts ...grpc.CallOption) (*PurgeIssueResponse, error) {
+	out := new(PurgeIssueResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/PurgeIssue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) TransitionIssue(ctx context.Context, in *TransitionIssueRequest, opts ...grpc.CallOption) (*TransitionIssueResponse, error) {
+	out := new(TransitionIssueResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/TransitionIssue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListIssueEvents(ctx context.Context, in *ListIssueEventsRequest, opts ...grpc.CallOption) (*ListIssueEventsResponse, error) {
+	out := new(ListIssueEventsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListIssueEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error) {
+	out := new(CreateProjectResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/CreateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error) {
+	out := new(GetProjectResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/GetProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListProjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error) {
+	out := new(UpdateProjectResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error) {
+	out := new(DeleteProjectResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/DeleteProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListProjectMembers(ctx context.Context, in *ListProjectMembersRequest, opts ...grpc.CallOption) (*ListProjectMembersResponse, error) {
+	out := new(ListProjectMembersResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListProjectMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) SetProjectMember(ctx context.Context, in *SetProjectMemberRequest, opts ...grpc.CallOption) (*SetProjectMemberResponse, error) {
+	out := new(SetProjectMemberResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/SetProjectMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) RemoveProjectMember(ctx context.Context, in *RemoveProjectMemberRequest, opts ...grpc.CallOption) (*RemoveProjectMemberResponse, error) {
+	out := new(RemoveProjectMemberResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/RemoveProjectMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) CreateLabel(ctx context.Context, in *CreateLabelRequest, opts ...grpc.CallOption) (*CreateLabelResponse, error) {
+	out := new(CreateLabelResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/CreateLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) GetLabel(ctx context.Context, in *GetLabelRequest, opts ...grpc.CallOption) (*GetLabelResponse, error) {
+	out := new(GetLabelResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/GetLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListLabels(ctx context.Context, in *ListLabelsRequest, opts ...grpc.CallOption) (*ListLabelsResponse, error) {
+	out := new(ListLabelsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) UpdateLabel(ctx context.Context, in *UpdateLabelRequest, opts ...grpc.CallOption) (*UpdateLabelResponse, error) {
+	out := new(UpdateLabelResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/UpdateLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) DeleteLabel(ctx context.Context, in *DeleteLabelRequest, opts ...grpc.CallOption) (*DeleteLabelResponse, error) {
+	out := new(DeleteLabelResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/DeleteLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) AddIssueLabel(ctx context.Context, in *AddIssueLabelRequest, opts ...grpc.CallOption) (*IssueLabelsResponse, error) {
+	out := new(IssueLabelsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/AddIssueLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) RemoveIssueLabel(ctx context.Context, in *RemoveIssueLabelRequest, opts ...grpc.CallOption) (*IssueLabelsResponse, error) {
+	out := new(IssueLabelsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/RemoveIssueLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*SetUserRoleResponse, error) {
+	out := new(SetUserRoleResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/SetUserRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) AssignIssue(ctx context.Context, in *AssignIssueRequest, opts ...grpc.CallOption) (*IssueAssigneesResponse, error) {
+	out := new(IssueAssigneesResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/AssignIssue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) UnassignIssue(ctx context.Context, in *UnassignIssueRequest, opts ...grpc.CallOption) (*IssueAssigneesResponse, error) {
+	out := new(IssueAssigneesResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/UnassignIssue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/CreateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*GetCommentResponse, error) {
+	out := new(GetCommentResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/GetComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
+	out := new(ListCommentsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListComments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*UpdateCommentResponse, error) {
+	out := new(UpdateCommentResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error) {
+	out := new(DeleteCommentResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuesServiceClient) ListCommentRevisions(ctx context.Context, in *ListCommentRevisionsRequest, opts ...grpc.CallOption) (*ListCommentRevisionsResponse, error) {
+	out := new(ListCommentRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/IssuesService/ListCommentRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IssuesServiceServer is the server API for IssuesService service.
 // All implementations must embed UnimplementedIssuesServiceServer
 // for forward compatibility
@@ -91,6 +411,38 @@ type IssuesServiceServer interface {
 	ListIssues(context.Context, *ListIssuesRequest) (*ListIssuesResponse, error)
 	UpdateIssue(context.Context, *UpdateIssueRequest) (*UpdateIssueResponse, error)
 	DeleteIssue(context.Context, *DeleteIssueRequest) (*DeleteIssueResponse, error)
+	ListDeletedIssues(context.Context, *ListDeletedIssuesRequest) (*ListDeletedIssuesResponse, error)
+	RestoreIssue(context.Context, *RestoreIssueRequest) (*RestoreIssueResponse, error)
+	PurgeIssue(context.Context, *PurgeIssueRequest) (*PurgeIssueResponse, error)
+	TransitionIssue(context.Context, *TransitionIssueRequest) (*TransitionIssueResponse, error)
+	ListIssueEvents(context.Context, *ListIssueEventsRequest) (*ListIssueEventsResponse, error)
+	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	ListProjectMembers(context.Context, *ListProjectMembersRequest) (*ListProjectMembersResponse, error)
+	SetProjectMember(context.Context, *SetProjectMemberRequest) (*SetProjectMemberResponse, error)
+	RemoveProjectMember(context.Context, *RemoveProjectMemberRequest) (*RemoveProjectMemberResponse, error)
+	CreateLabel(context.Context, *CreateLabelRequest) (*CreateLabelResponse, error)
+	GetLabel(context.Context, *GetLabelRequest) (*GetLabelResponse, error)
+	ListLabels(context.Context, *ListLabelsRequest) (*ListLabelsResponse, error)
+	UpdateLabel(context.Context, *UpdateLabelRequest) (*UpdateLabelResponse, error)
+	DeleteLabel(context.Context, *DeleteLabelRequest) (*DeleteLabelResponse, error)
+	AddIssueLabel(context.Context, *AddIssueLabelRequest) (*IssueLabelsResponse, error)
+	RemoveIssueLabel(context.Context, *RemoveIssueLabelRequest) (*IssueLabelsResponse, error)
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	SetUserRole(context.Context, *SetUserRoleRequest) (*SetUserRoleResponse, error)
+	AssignIssue(context.Context, *AssignIssueRequest) (*IssueAssigneesResponse, error)
+	UnassignIssue(context.Context, *UnassignIssueRequest) (*IssueAssigneesResponse, error)
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	GetComment(context.Context, *GetCommentRequest) (*GetCommentResponse, error)
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	ListCommentRevisions(context.Context, *ListCommentRevisionsRequest) (*ListCommentRevisionsResponse, error)
 	mustEmbedUnimplementedIssuesServiceServer()
 }
 
@@ -113,6 +465,102 @@ func (UnimplementedIssuesServiceServer) UpdateIssue(context.Context, *UpdateIssu
 func (UnimplementedIssuesServiceServer) DeleteIssue(context.Context, *DeleteIssueRequest) (*DeleteIssueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteIssue not implemented")
 }
+func (UnimplementedIssuesServiceServer) ListDeletedIssues(context.Context, *ListDeletedIssuesRequest) (*ListDeletedIssuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedIssues not implemented")
+}
+func (UnimplementedIssuesServiceServer) RestoreIssue(context.Context, *RestoreIssueRequest) (*RestoreIssueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreIssue not implemented")
+}
+func (UnimplementedIssuesServiceServer) PurgeIssue(context.Context, *PurgeIssueRequest) (*PurgeIssueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeIssue not implemented")
+}
+func (UnimplementedIssuesServiceServer) TransitionIssue(context.Context, *TransitionIssueRequest) (*TransitionIssueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionIssue not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListIssueEvents(context.Context, *ListIssueEventsRequest) (*ListIssueEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssueEvents not implemented")
+}
+func (UnimplementedIssuesServiceServer) CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedIssuesServiceServer) GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedIssuesServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedIssuesServiceServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListProjectMembers(context.Context, *ListProjectMembersRequest) (*ListProjectMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjectMembers not implemented")
+}
+func (UnimplementedIssuesServiceServer) SetProjectMember(context.Context, *SetProjectMemberRequest) (*SetProjectMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectMember not implemented")
+}
+func (UnimplementedIssuesServiceServer) RemoveProjectMember(context.Context, *RemoveProjectMemberRequest) (*RemoveProjectMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProjectMember not implemented")
+}
+func (UnimplementedIssuesServiceServer) CreateLabel(context.Context, *CreateLabelRequest) (*CreateLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) GetLabel(context.Context, *GetLabelRequest) (*GetLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListLabels(context.Context, *ListLabelsRequest) (*ListLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLabels not implemented")
+}
+func (UnimplementedIssuesServiceServer) UpdateLabel(context.Context, *UpdateLabelRequest) (*UpdateLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) DeleteLabel(context.Context, *DeleteLabelRequest) (*DeleteLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) AddIssueLabel(context.Context, *AddIssueLabelRequest) (*IssueLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddIssueLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) RemoveIssueLabel(context.Context, *RemoveIssueLabelRequest) (*IssueLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveIssueLabel not implemented")
+}
+func (UnimplementedIssuesServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedIssuesServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedIssuesServiceServer) SetUserRole(context.Context, *SetUserRoleRequest) (*SetUserRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserRole not implemented")
+}
+func (UnimplementedIssuesServiceServer) AssignIssue(context.Context, *AssignIssueRequest) (*IssueAssigneesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignIssue not implemented")
+}
+func (UnimplementedIssuesServiceServer) UnassignIssue(context.Context, *UnassignIssueRequest) (*IssueAssigneesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignIssue not implemented")
+}
+func (UnimplementedIssuesServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedIssuesServiceServer) GetComment(context.Context, *GetCommentRequest) (*GetCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComment not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedIssuesServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*UpdateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedIssuesServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedIssuesServiceServer) ListCommentRevisions(context.Context, *ListCommentRevisionsRequest) (*ListCommentRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommentRevisions not implemented")
+}
 func (UnimplementedIssuesServiceServer) mustEmbedUnimplementedIssuesServiceServer() {}
 
 // UnsafeIssuesServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,32 +664,736 @@ func _IssuesService_DeleteIssue_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-// IssuesService_ServiceDesc is the grpc.ServiceDesc for IssuesService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var IssuesService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "IssuesService",
-	HandlerType: (*IssuesServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateIssue",
-			Handler:    _IssuesService_CreateIssue_Handler,
-		},
-		{
-			MethodName: "GetIssue",
-			Handler:    _IssuesService_GetIssue_Handler,
-		},
-		{
-			MethodName: "ListIssues",
-			Handler:    _IssuesService_ListIssues_Handler,
-		},
-		{
-			MethodName: "UpdateIssue",
-			Handler:    _IssuesService_UpdateIssue_Handler,
-		},
-		{
-			MethodName: "DeleteIssue",
-			Handler:    _IssuesService_DeleteIssue_Handler,
+func _IssuesService_ListDeletedIssues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedIssuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListDeletedIssues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListDeletedIssues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListDeletedIssues(ctx, req.(*ListDeletedIssuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_RestoreIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).RestoreIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/RestoreIssue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).RestoreIssue(ctx, req.(*RestoreIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_PurgeIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).PurgeIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/PurgeIssue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).PurgeIssue(ctx, req.(*PurgeIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_TransitionIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).TransitionIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/TransitionIssue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).TransitionIssue(ctx, req.(*TransitionIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListIssueEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssueEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListIssueEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListIssueEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListIssueEvents(ctx, req.(*ListIssueEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/CreateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/GetProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListProjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/DeleteProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).DeleteProject(ctx, req.(*DeleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListProjectMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListProjectMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListProjectMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListProjectMembers(ctx, req.(*ListProjectMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_SetProjectMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).SetProjectMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/SetProjectMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).SetProjectMember(ctx, req.(*SetProjectMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_RemoveProjectMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProjectMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).RemoveProjectMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/RemoveProjectMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).RemoveProjectMember(ctx, req.(*RemoveProjectMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_CreateLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).CreateLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/CreateLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).CreateLabel(ctx, req.(*CreateLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_GetLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).GetLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/GetLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).GetLabel(ctx, req.(*GetLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListLabels(ctx, req.(*ListLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_UpdateLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).UpdateLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/UpdateLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).UpdateLabel(ctx, req.(*UpdateLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_DeleteLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).DeleteLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/DeleteLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).DeleteLabel(ctx, req.(*DeleteLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_AddIssueLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddIssueLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).AddIssueLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/AddIssueLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).AddIssueLabel(ctx, req.(*AddIssueLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_RemoveIssueLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveIssueLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).RemoveIssueLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/RemoveIssueLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).RemoveIssueLabel(ctx, req.(*RemoveIssueLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_SetUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).SetUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/SetUserRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).SetUserRole(ctx, req.(*SetUserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_AssignIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).AssignIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/AssignIssue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).AssignIssue(ctx, req.(*AssignIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_UnassignIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).UnassignIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/UnassignIssue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).UnassignIssue(ctx, req.(*UnassignIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/CreateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_GetComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).GetComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/GetComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).GetComment(ctx, req.(*GetCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListComments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuesService_ListCommentRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).ListCommentRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/IssuesService/ListCommentRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).ListCommentRevisions(ctx, req.(*ListCommentRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IssuesService_ServiceDesc is the grpc.ServiceDesc for IssuesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IssuesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "IssuesService",
+	HandlerType: (*IssuesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateIssue",
+			Handler:    _IssuesService_CreateIssue_Handler,
+		},
+		{
+			MethodName: "GetIssue",
+			Handler:    _IssuesService_GetIssue_Handler,
+		},
+		{
+			MethodName: "ListIssues",
+			Handler:    _IssuesService_ListIssues_Handler,
+		},
+		{
+			MethodName: "UpdateIssue",
+			Handler:    _IssuesService_UpdateIssue_Handler,
+		},
+		{
+			MethodName: "DeleteIssue",
+			Handler:    _IssuesService_DeleteIssue_Handler,
+		},
+		{
+			MethodName: "ListDeletedIssues",
+			Handler:    _IssuesService_ListDeletedIssues_Handler,
+		},
+		{
+			MethodName: "RestoreIssue",
+			Handler:    _IssuesService_RestoreIssue_Handler,
+		},
+		{
+			MethodName: "PurgeIssue",
+			Handler:    _IssuesService_PurgeIssue_Handler,
+		},
+		{
+			MethodName: "TransitionIssue",
+			Handler:    _IssuesService_TransitionIssue_Handler,
+		},
+		{
+			MethodName: "ListIssueEvents",
+			Handler:    _IssuesService_ListIssueEvents_Handler,
+		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _IssuesService_CreateProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _IssuesService_GetProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _IssuesService_ListProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _IssuesService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _IssuesService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "ListProjectMembers",
+			Handler:    _IssuesService_ListProjectMembers_Handler,
+		},
+		{
+			MethodName: "SetProjectMember",
+			Handler:    _IssuesService_SetProjectMember_Handler,
+		},
+		{
+			MethodName: "RemoveProjectMember",
+			Handler:    _IssuesService_RemoveProjectMember_Handler,
+		},
+		{
+			MethodName: "CreateLabel",
+			Handler:    _IssuesService_CreateLabel_Handler,
+		},
+		{
+			MethodName: "GetLabel",
+			Handler:    _IssuesService_GetLabel_Handler,
+		},
+		{
+			MethodName: "ListLabels",
+			Handler:    _IssuesService_ListLabels_Handler,
+		},
+		{
+			MethodName: "UpdateLabel",
+			Handler:    _IssuesService_UpdateLabel_Handler,
+		},
+		{
+			MethodName: "DeleteLabel",
+			Handler:    _IssuesService_DeleteLabel_Handler,
+		},
+		{
+			MethodName: "AddIssueLabel",
+			Handler:    _IssuesService_AddIssueLabel_Handler,
+		},
+		{
+			MethodName: "RemoveIssueLabel",
+			Handler:    _IssuesService_RemoveIssueLabel_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _IssuesService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _IssuesService_GetUser_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _IssuesService_ListUsers_Handler,
+		},
+		{
+			MethodName: "SetUserRole",
+			Handler:    _IssuesService_SetUserRole_Handler,
+		},
+		{
+			MethodName: "AssignIssue",
+			Handler:    _IssuesService_AssignIssue_Handler,
+		},
+		{
+			MethodName: "UnassignIssue",
+			Handler:    _IssuesService_UnassignIssue_Handler,
+		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _IssuesService_CreateComment_Handler,
+		},
+		{
+			MethodName: "GetComment",
+			Handler:    _IssuesService_GetComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _IssuesService_ListComments_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _IssuesService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _IssuesService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ListCommentRevisions",
+			Handler:    _IssuesService_ListCommentRevisions_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
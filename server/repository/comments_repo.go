@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CommentRepository interface {
+	GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error)
+	ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error)
+	ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error)
+	CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error)
+	UpdateComment(ctx context.Context, req *api.UpdateCommentRequest, editedBy string) (*api.UpdateCommentResponse, error)
+	DeleteComment(ctx context.Context, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error)
+}
+
+type commentRepository struct {
+	db           Store
+	commentQuery query.CommentQuery
+}
+
+func NewCommentRepository(db Store, commentQuery query.CommentQuery) CommentRepository {
+	return &commentRepository{
+		db:           db,
+		commentQuery: commentQuery,
+	}
+}
+
+func (r *commentRepository) GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error) {
+	var comment *api.GetCommentResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		comment, err = r.commentQuery.GetComment(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error) {
+	var comments *api.ListCommentsResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		comments, err = r.commentQuery.ListComments(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error) {
+	var revisions *api.ListCommentRevisionsResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		revisions, err = r.commentQuery.ListCommentRevisions(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *commentRepository) CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error) {
+	var comment *api.CreateCommentResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		comment, err = r.commentQuery.CreateComment(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) UpdateComment(ctx context.Context, req *api.UpdateCommentRequest, editedBy string) (*api.UpdateCommentResponse, error) {
+	var comment *api.UpdateCommentResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		comment, err = r.commentQuery.UpdateComment(ctx, tx, req, editedBy)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) DeleteComment(ctx context.Context, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error) {
+	var res *api.DeleteCommentResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.commentQuery.DeleteComment(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return res, nil
+}
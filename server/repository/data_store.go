@@ -5,39 +5,46 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/daffaromero/matesite/server/config"
 	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/metrics"
+	"github.com/daffaromero/matesite/server/repository/query"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 )
 
+// Store runs queries, translating the errors of the database to domain errors
+// with query.DBError.
 type Store interface {
 	WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error
 	WithoutTx(ctx context.Context, fn func(*pgxpool.Pool) error) error
 }
 
 type store struct {
-	db     *pgxpool.Pool
-	logger *logger.Log
+	db      *pgxpool.Pool
+	timeout time.Duration
 }
 
-func NewStore(db *pgxpool.Pool) Store {
-	return &store{db: db}
+// NewStore returns a Store running transactions on db, each bounded by
+// timeout.
+func NewStore(db *pgxpool.Pool, timeout time.Duration) Store {
+	return &store{db: db, timeout: timeout}
 }
 
 func (s *store) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.TimeOutDuration)*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", query.DBError(err))
 	}
 
 	defer func() {
 		if err != nil {
+			metrics.Transactions.WithLabelValues("rollback").Inc()
 			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
-				s.logger.Error(fmt.Sprintf("Rollback error: %v, original error: %v", rollbackErr, err))
+				logger.FromContext(ctx).Error("Failed to roll back transaction", zap.Error(rollbackErr), zap.NamedError("original_error", err))
 
 				err = fmt.Errorf("rollback error: %v (original error: %w)", rollbackErr, err)
 			}
@@ -45,19 +52,20 @@ func (s *store) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	}()
 
 	if err = fn(tx); err != nil {
-		return fmt.Errorf("transaction function failed: %w", err)
+		return fmt.Errorf("transaction function failed: %w", query.DBError(err))
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", query.DBError(err))
 	}
+	metrics.Transactions.WithLabelValues("commit").Inc()
 
 	return nil
 }
 
 func (s *store) WithoutTx(ctx context.Context, fn func(*pgxpool.Pool) error) error {
 	if err := fn(s.db); err != nil {
-		return err
+		return query.DBError(err)
 	}
 
 	return nil
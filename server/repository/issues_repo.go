@@ -3,37 +3,51 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	api "github.com/daffaromero/matesite/server/protobuf"
 	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/daffaromero/matesite/server/tracing"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type IssueRepository interface {
 	GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error)
 	ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error)
-	CreateIssue(ctx context.Context, req *api.CreateIssueRequest) (*api.CreateIssueResponse, error)
-	UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error)
-	DeleteIssue(ctx context.Context, id *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error)
+	CreateIssue(ctx context.Context, req *api.CreateIssueRequest, actor string) (*api.CreateIssueResponse, error)
+	UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest, actor string) (*api.UpdateIssueResponse, error)
+	DeleteIssue(ctx context.Context, id *api.DeleteIssueRequest, actor string) (*api.DeleteIssueResponse, error)
+	TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest, from api.IssueStatus) (*api.TransitionIssueResponse, error)
+	ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error)
+	ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error)
+	RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest, actor string) (*api.RestoreIssueResponse, error)
+	PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest, actor string) (*api.PurgeIssueResponse, error)
+	PurgeExpiredIssues(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
 }
 
 type issueRepository struct {
 	db         Store
 	issueQuery query.IssueQuery
+	eventQuery query.IssueEventQuery
 }
 
-func NewIssueRepository(db Store, issueQuery query.IssueQuery) IssueRepository {
+func NewIssueRepository(db Store, issueQuery query.IssueQuery, eventQuery query.IssueEventQuery) IssueRepository {
 	return &issueRepository{
 		db:         db,
 		issueQuery: issueQuery,
+		eventQuery: eventQuery,
 	}
 }
 
-func (r *issueRepository) GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error) {
+func (r *issueRepository) GetIssue(ctx context.Context, req *api.GetIssueRequest) (_ *api.GetIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.GetIssue")
+	defer func() { tracing.End(span, err) }()
+
 	var issue *api.GetIssueResponse
 
-	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+	err = r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
 		var err error
 		issue, err = r.issueQuery.GetIssue(ctx, req)
 		return err
@@ -44,10 +58,13 @@ func (r *issueRepository) GetIssue(ctx context.Context, req *api.GetIssueRequest
 	return issue, nil
 }
 
-func (r *issueRepository) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error) {
+func (r *issueRepository) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (_ *api.ListIssuesResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.ListIssues")
+	defer func() { tracing.End(span, err) }()
+
 	var issues *api.ListIssuesResponse
 
-	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+	err = r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
 		var err error
 		issues, err = r.issueQuery.ListIssues(ctx, req)
 		return err
@@ -58,13 +75,19 @@ func (r *issueRepository) ListIssues(ctx context.Context, req *api.ListIssuesReq
 	return issues, nil
 }
 
-func (r *issueRepository) CreateIssue(ctx context.Context, req *api.CreateIssueRequest) (*api.CreateIssueResponse, error) {
+func (r *issueRepository) CreateIssue(ctx context.Context, req *api.CreateIssueRequest, actor string) (_ *api.CreateIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.CreateIssue")
+	defer func() { tracing.End(span, err) }()
+
 	var issue *api.CreateIssueResponse
 
-	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
 		var err error
 		issue, err = r.issueQuery.CreateIssue(ctx, tx, req)
-		return err
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventCreate, actor, nil, issue.Issue)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create issue: %w", err)
@@ -72,13 +95,22 @@ func (r *issueRepository) CreateIssue(ctx context.Context, req *api.CreateIssueR
 	return issue, nil
 }
 
-func (r *issueRepository) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error) {
+func (r *issueRepository) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest, actor string) (_ *api.UpdateIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.UpdateIssue")
+	defer func() { tracing.End(span, err) }()
+
 	var issue *api.UpdateIssueResponse
 
-	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
-		var err error
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.issueQuery.GetIssueForUpdate(ctx, tx, req.Issue.ProjectId, req.Issue.Id)
+		if err != nil {
+			return err
+		}
 		issue, err = r.issueQuery.UpdateIssue(ctx, tx, req)
-		return err
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventUpdate, actor, before, issue.Issue)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update issue: %w", err)
@@ -86,16 +118,173 @@ func (r *issueRepository) UpdateIssue(ctx context.Context, req *api.UpdateIssueR
 	return issue, nil
 }
 
-func (r *issueRepository) DeleteIssue(ctx context.Context, id *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error) {
+func (r *issueRepository) DeleteIssue(ctx context.Context, id *api.DeleteIssueRequest, actor string) (_ *api.DeleteIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.DeleteIssue")
+	defer func() { tracing.End(span, err) }()
+
 	var res *api.DeleteIssueResponse
 
-	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
-		var err error
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.issueQuery.GetIssueForUpdate(ctx, tx, id.ProjectId, id.Id)
+		if err != nil {
+			return err
+		}
 		res, err = r.issueQuery.DeleteIssue(ctx, tx, id)
-		return err
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventDelete, actor, before, nil)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete issue: %w", err)
 	}
 	return res, nil
 }
+
+func (r *issueRepository) TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest, from api.IssueStatus) (_ *api.TransitionIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.TransitionIssue")
+	defer func() { tracing.End(span, err) }()
+
+	var res *api.TransitionIssueResponse
+
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.issueQuery.GetIssueForUpdate(ctx, tx, req.ProjectId, req.Id)
+		if err != nil {
+			return err
+		}
+		res, err = r.issueQuery.TransitionIssue(ctx, tx, req, from)
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventTransition, req.ChangedBy, before, res.Issue)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to transition issue: %w", err)
+	}
+	return res, nil
+}
+
+func (r *issueRepository) ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (_ *api.ListIssueEventsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.ListIssueEvents")
+	defer func() { tracing.End(span, err) }()
+
+	var events *api.ListIssueEventsResponse
+
+	err = r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		events, err = r.eventQuery.ListIssueEvents(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *issueRepository) ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (_ *api.ListDeletedIssuesResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.ListDeletedIssues")
+	defer func() { tracing.End(span, err) }()
+
+	var issues *api.ListDeletedIssuesResponse
+
+	err = r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		issues, err = r.issueQuery.ListDeletedIssues(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted issues: %w", err)
+	}
+	return issues, nil
+}
+
+func (r *issueRepository) RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest, actor string) (_ *api.RestoreIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.RestoreIssue")
+	defer func() { tracing.End(span, err) }()
+
+	var res *api.RestoreIssueResponse
+
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.issueQuery.GetDeletedIssueForUpdate(ctx, tx, req.ProjectId, req.Id)
+		if err != nil {
+			return err
+		}
+		res, err = r.issueQuery.RestoreIssue(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventRestore, actor, before, res.Issue)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore issue: %w", err)
+	}
+	return res, nil
+}
+
+func (r *issueRepository) PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest, actor string) (_ *api.PurgeIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.PurgeIssue")
+	defer func() { tracing.End(span, err) }()
+
+	var res *api.PurgeIssueResponse
+
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		before, err := r.issueQuery.GetDeletedIssueForUpdate(ctx, tx, req.ProjectId, req.Id)
+		if err != nil {
+			return err
+		}
+		res, err = r.issueQuery.PurgeIssue(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(ctx, tx, query.IssueEventPurge, actor, before, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge issue: %w", err)
+	}
+	return res, nil
+}
+
+// PurgeExpiredIssues purges one batch of issues deleted before deletedBefore
+// and reports how many were purged. The purges are recorded without an actor.
+func (r *issueRepository) PurgeExpiredIssues(ctx context.Context, deletedBefore time.Time, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "IssueRepository.PurgeExpiredIssues")
+	defer func() { tracing.End(span, err) }()
+
+	var purged int
+
+	err = r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		issues, err := r.issueQuery.PurgeExpiredIssues(ctx, tx, deletedBefore, limit)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if err := r.recordEvent(ctx, tx, query.IssueEventPurge, "", issue, nil); err != nil {
+				return err
+			}
+		}
+		purged = len(issues)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired issues: %w", err)
+	}
+	return purged, nil
+}
+
+// recordEvent writes the audit record of a change in the transaction that
+// made it, so the change and its record commit or roll back together.
+func (r *issueRepository) recordEvent(ctx context.Context, tx pgx.Tx, operation string, actor string, before *api.Issue, after *api.Issue) error {
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+
+	return r.eventQuery.CreateIssueEvent(ctx, tx, &api.IssueEvent{
+		IssueId:    subject.Id,
+		ProjectId:  subject.ProjectId,
+		ActorId:    actor,
+		Operation:  operation,
+		Changes:    query.IssueChanges(before, after),
+		OccurredAt: timestamppb.New(time.Now()),
+	})
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LabelRepository interface {
+	GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error)
+	ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error)
+	CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error)
+	UpdateLabel(ctx context.Context, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error)
+	DeleteLabel(ctx context.Context, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error)
+	AddIssueLabel(ctx context.Context, req *api.AddIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error)
+	RemoveIssueLabel(ctx context.Context, req *api.RemoveIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error)
+}
+
+type labelRepository struct {
+	db         Store
+	labelQuery query.LabelQuery
+}
+
+func NewLabelRepository(db Store, labelQuery query.LabelQuery) LabelRepository {
+	return &labelRepository{
+		db:         db,
+		labelQuery: labelQuery,
+	}
+}
+
+func (r *labelRepository) GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error) {
+	var label *api.GetLabelResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		label, err = r.labelQuery.GetLabel(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	return label, nil
+}
+
+func (r *labelRepository) ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error) {
+	var labels *api.ListLabelsResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		labels, err = r.labelQuery.ListLabels(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error) {
+	var label *api.CreateLabelResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		label, err = r.labelQuery.CreateLabel(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+	return label, nil
+}
+
+func (r *labelRepository) UpdateLabel(ctx context.Context, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error) {
+	var label *api.UpdateLabelResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		label, err = r.labelQuery.UpdateLabel(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+	return label, nil
+}
+
+func (r *labelRepository) DeleteLabel(ctx context.Context, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error) {
+	var res *api.DeleteLabelResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.labelQuery.DeleteLabel(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete label: %w", err)
+	}
+	return res, nil
+}
+
+func (r *labelRepository) AddIssueLabel(ctx context.Context, req *api.AddIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error) {
+	var res *api.IssueLabelsResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.labelQuery.AddIssueLabel(ctx, tx, req, actor)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add issue label: %w", err)
+	}
+	return res, nil
+}
+
+func (r *labelRepository) RemoveIssueLabel(ctx context.Context, req *api.RemoveIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error) {
+	var res *api.IssueLabelsResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.labelQuery.RemoveIssueLabel(ctx, tx, req, actor)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove issue label: %w", err)
+	}
+	return res, nil
+}
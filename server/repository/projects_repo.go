@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProjectRepository interface {
+	GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error)
+	ListProjects(ctx context.Context, req *api.ListProjectsRequest, memberID string) (*api.ListProjectsResponse, error)
+	GetProjectRole(ctx context.Context, projectID string, userID string) (string, error)
+	CreateProject(ctx context.Context, req *api.CreateProjectRequest, ownerID string) (*api.CreateProjectResponse, error)
+	UpdateProject(ctx context.Context, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error)
+	DeleteProject(ctx context.Context, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error)
+	ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error)
+	SetProjectMember(ctx context.Context, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error)
+	RemoveProjectMember(ctx context.Context, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error)
+}
+
+type projectRepository struct {
+	db           Store
+	projectQuery query.ProjectQuery
+}
+
+func NewProjectRepository(db Store, projectQuery query.ProjectQuery) ProjectRepository {
+	return &projectRepository{
+		db:           db,
+		projectQuery: projectQuery,
+	}
+}
+
+func (r *projectRepository) GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error) {
+	var project *api.GetProjectResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		project, err = r.projectQuery.GetProject(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+func (r *projectRepository) ListProjects(ctx context.Context, req *api.ListProjectsRequest, memberID string) (*api.ListProjectsResponse, error) {
+	var projects *api.ListProjectsResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		projects, err = r.projectQuery.ListProjects(ctx, req, memberID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) GetProjectRole(ctx context.Context, projectID string, userID string) (string, error) {
+	var role string
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		role, err = r.projectQuery.GetProjectRole(ctx, projectID, userID)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get project role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, req *api.CreateProjectRequest, ownerID string) (*api.CreateProjectResponse, error) {
+	var project *api.CreateProjectResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		project, err = r.projectQuery.CreateProject(ctx, tx, req, ownerID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return project, nil
+}
+
+func (r *projectRepository) UpdateProject(ctx context.Context, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error) {
+	var project *api.UpdateProjectResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		project, err = r.projectQuery.UpdateProject(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+	return project, nil
+}
+
+func (r *projectRepository) DeleteProject(ctx context.Context, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error) {
+	var res *api.DeleteProjectResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.projectQuery.DeleteProject(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %w", err)
+	}
+	return res, nil
+}
+
+func (r *projectRepository) ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error) {
+	var members *api.ListProjectMembersResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		members, err = r.projectQuery.ListProjectMembers(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *projectRepository) SetProjectMember(ctx context.Context, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error) {
+	var member *api.SetProjectMemberResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		member, err = r.projectQuery.SetProjectMember(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set project member: %w", err)
+	}
+	return member, nil
+}
+
+func (r *projectRepository) RemoveProjectMember(ctx context.Context, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error) {
+	var res *api.RemoveProjectMemberResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.projectQuery.RemoveProjectMember(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove project member: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,301 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CommentQuery interface {
+	GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error)
+	ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error)
+	ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error)
+	CreateComment(ctx context.Context, tx pgx.Tx, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error)
+	UpdateComment(ctx context.Context, tx pgx.Tx, req *api.UpdateCommentRequest, editedBy string) (*api.UpdateCommentResponse, error)
+	DeleteComment(ctx context.Context, tx pgx.Tx, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error)
+}
+
+const commentColumns = `c.id, c.issue_id, c.author_id, c.parent_id, c.body, c.created_at, c.updated_at, c.deleted_at,
+	(SELECT COUNT(*) FROM comment_revisions r WHERE r.comment_id = c.id)`
+
+type commentQuery struct {
+	db *pgxpool.Pool
+}
+
+func NewCommentQuery(db *pgxpool.Pool) CommentQuery {
+	return &commentQuery{
+		db: db,
+	}
+}
+
+func (q *commentQuery) GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error) {
+	if req == nil || req.IssueId == "" || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID and comment ID cannot be empty.")
+	}
+	if err := issueExists(ctx, q.db, req.ProjectId, req.IssueId); err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + commentColumns + ` FROM comments c WHERE c.id = $1 AND c.issue_id = $2 AND c.deleted_at IS NULL`
+
+	comment, err := scanComment(q.db.QueryRow(ctx, query, req.Id, req.IssueId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Comment with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	return &api.GetCommentResponse{
+		Comment: comment,
+	}, nil
+}
+
+func (q *commentQuery) ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error) {
+	if req == nil || req.IssueId == "" {
+		return nil, errs.InvalidArgument("Issue ID cannot be empty.")
+	}
+
+	after, err := decodeCursor(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && after.Rank != nil {
+		return nil, ErrInvalidPageToken
+	}
+	limit := pageSize(req.PageSize)
+
+	if err := issueExists(ctx, q.db, req.ProjectId, req.IssueId); err != nil {
+		return nil, err
+	}
+
+	args := []any{req.IssueId}
+	conditions := "c.issue_id = $1 AND c.deleted_at IS NULL"
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions += " AND (c.created_at, c.id) > ($2, $3::uuid)"
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`SELECT %s FROM comments c WHERE %s ORDER BY c.created_at, c.id LIMIT $%d`, commentColumns, conditions, len(args))
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*api.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextPageToken string
+	if len(comments) > limit {
+		comments = comments[:limit]
+		last := comments[limit-1]
+		nextPageToken = encodeCursor(cursor{CreatedAt: last.CreatedAt.AsTime(), ID: last.Id})
+	}
+
+	return &api.ListCommentsResponse{
+		Comments:      comments,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (q *commentQuery) ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error) {
+	if req == nil || req.IssueId == "" || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID and comment ID cannot be empty.")
+	}
+	if err := issueExists(ctx, q.db, req.ProjectId, req.IssueId); err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	check := `SELECT EXISTS (SELECT 1 FROM comments WHERE id = $1 AND issue_id = $2 AND deleted_at IS NULL)`
+	if err := q.db.QueryRow(ctx, check, req.Id, req.IssueId).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errs.NotFound(fmt.Sprintf("Comment with ID %s not found.", req.Id))
+	}
+
+	query := `SELECT comment_id, body, edited_by, edited_at FROM comment_revisions WHERE comment_id = $1 ORDER BY edited_at, id`
+
+	rows, err := q.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Comment revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*api.CommentRevision
+	for rows.Next() {
+		var revision api.CommentRevision
+		var editedBy *string
+		var editedAt time.Time
+		if err := rows.Scan(&revision.CommentId, &revision.Body, &editedBy, &editedAt); err != nil {
+			return nil, err
+		}
+		if editedBy != nil {
+			revision.EditedBy = *editedBy
+		}
+		revision.EditedAt = timestamppb.New(editedAt)
+		revisions = append(revisions, &revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.ListCommentRevisionsResponse{
+		Revisions: revisions,
+	}, nil
+}
+
+func (q *commentQuery) CreateComment(ctx context.Context, tx pgx.Tx, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error) {
+	if req == nil || req.Comment == nil {
+		return nil, errs.InvalidArgument("Please provide a valid comment.")
+	}
+	if err := issueExists(ctx, tx, req.ProjectId, req.Comment.IssueId); err != nil {
+		return nil, err
+	}
+	if req.Comment.ParentId != "" {
+		var exists bool
+		check := `SELECT EXISTS (SELECT 1 FROM comments WHERE id = $1 AND issue_id = $2 AND deleted_at IS NULL)`
+		if err := tx.QueryRow(ctx, check, req.Comment.ParentId, req.Comment.IssueId).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errs.NotFound(fmt.Sprintf("Parent comment with ID %s not found.", req.Comment.ParentId))
+		}
+	}
+
+	query := `INSERT INTO comments AS c (id, issue_id, author_id, parent_id, body, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7) RETURNING ` + commentColumns
+
+	createdAt := req.Comment.CreatedAt.AsTime()
+	updatedAt := req.Comment.UpdatedAt.AsTime()
+
+	comment, err := scanComment(tx.QueryRow(ctx, query, req.Comment.Id, req.Comment.IssueId, req.Comment.AuthorId, req.Comment.ParentId, req.Comment.Body, createdAt, updatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateCommentResponse{
+		Comment: comment,
+	}, nil
+}
+
+func (q *commentQuery) UpdateComment(ctx context.Context, tx pgx.Tx, req *api.UpdateCommentRequest, editedBy string) (*api.UpdateCommentResponse, error) {
+	if req == nil || req.Comment == nil {
+		return nil, errs.InvalidArgument("Comment cannot be empty.")
+	}
+	if err := issueExists(ctx, tx, req.ProjectId, req.Comment.IssueId); err != nil {
+		return nil, err
+	}
+
+	var previous string
+	lock := `SELECT body FROM comments WHERE id = $1 AND issue_id = $2 AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRow(ctx, lock, req.Comment.Id, req.Comment.IssueId).Scan(&previous); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Comment with ID %s not found.", req.Comment.Id))
+		}
+		return nil, err
+	}
+
+	updatedAt := time.Now()
+	if req.Comment.UpdatedAt != nil {
+		updatedAt = req.Comment.UpdatedAt.AsTime()
+	}
+
+	revision := `INSERT INTO comment_revisions (comment_id, body, edited_by, edited_at) VALUES ($1, $2, NULLIF($3, '')::uuid, $4)`
+	if _, err := tx.Exec(ctx, revision, req.Comment.Id, previous, editedBy, updatedAt); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE comments AS c SET body = $2, updated_at = $3 WHERE c.id = $1 RETURNING ` + commentColumns
+
+	comment, err := scanComment(tx.QueryRow(ctx, query, req.Comment.Id, req.Comment.Body, updatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UpdateCommentResponse{
+		Comment: comment,
+	}, nil
+}
+
+func (q *commentQuery) DeleteComment(ctx context.Context, tx pgx.Tx, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error) {
+	if req.IssueId == "" || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID and comment ID cannot be empty.")
+	}
+	if err := issueExists(ctx, tx, req.ProjectId, req.IssueId); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE comments SET deleted_at = $3 WHERE id = $1 AND issue_id = $2 AND deleted_at IS NULL`
+
+	tag, err := tx.Exec(ctx, query, req.Id, req.IssueId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("Comment with ID %s not found.", req.Id))
+	}
+
+	return &api.DeleteCommentResponse{
+		Success: true,
+	}, nil
+}
+
+// issueExists checks that a live issue exists within the given project, so
+// that nested resources cannot be reached through another project's routes.
+func issueExists(ctx context.Context, db querier, projectID string, issueID string) error {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM issues WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL)`
+	if err := db.QueryRow(ctx, query, issueID, projectID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errs.NotFound(fmt.Sprintf("Issue with ID %s not found.", issueID))
+	}
+	return nil
+}
+
+func scanComment(row pgx.Row) (*api.Comment, error) {
+	var comment api.Comment
+	var parentID *string
+	var createdAt, updatedAt, deletedAt *time.Time
+	var revisions int64
+
+	if err := row.Scan(&comment.Id, &comment.IssueId, &comment.AuthorId, &parentID, &comment.Body, &createdAt, &updatedAt, &deletedAt, &revisions); err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		comment.ParentId = *parentID
+	}
+	if createdAt != nil {
+		comment.CreatedAt = timestamppb.New(*createdAt)
+	}
+	if updatedAt != nil {
+		comment.UpdatedAt = timestamppb.New(*updatedAt)
+	}
+	if deletedAt != nil {
+		comment.DeletedAt = timestamppb.New(*deletedAt)
+	}
+	comment.RevisionCount = int32(revisions)
+
+	return &comment, nil
+}
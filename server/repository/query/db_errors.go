@@ -0,0 +1,86 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/puddle/v2"
+)
+
+// Errors DBError translates database errors to. Services match them with
+// errors.Is to tell clients which resource was at fault.
+var (
+	// ErrAlreadyExists is a unique constraint violation.
+	ErrAlreadyExists = errs.Conflict("Resource already exists.")
+	// ErrReferenceNotFound is a foreign key violation by a row that refers to
+	// a row which does not exist.
+	ErrReferenceNotFound = errs.InvalidArgument("A resource it refers to does not exist.")
+	// ErrStillReferenced is a foreign key violation by the deletion of a row
+	// other rows still refer to.
+	ErrStillReferenced = errs.Conflict("Resource is still referred to by other resources.")
+	// ErrInvalidValue is a value the database cannot store in its column,
+	// such as an ID that is not a UUID.
+	ErrInvalidValue = errs.InvalidArgument("Invalid value, such as an ID that is not a UUID.")
+	// ErrDatabaseUnavailable is a database that cannot be reached or does not
+	// accept connections.
+	ErrDatabaseUnavailable = errs.Unavailable("The database is unavailable, please retry later.")
+)
+
+// SQLSTATE codes of the errors DBError translates.
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation           = "23505"
+	pgForeignKeyViolation       = "23503"
+	pgInvalidTextRepresentation = "22P02"
+	pgConnectionExceptionClass  = "08"
+	pgInsufficientResourceClass = "53"
+	pgAdminShutdown             = "57P01"
+	pgCrashShutdown             = "57P02"
+	pgCannotConnectNow          = "57P03"
+)
+
+// DBError translates err to the domain error it stands for, wrapping it so
+// that operators still see what the database reported. Errors that are
+// already domain errors, or that the database gives no reason for, are
+// returned as is, as are context errors, which errs.From already
+// classifies.
+func DBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var domainErr *errs.Error
+	if errors.As(err, &domainErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+		case pgErr.Code == pgForeignKeyViolation && strings.HasPrefix(pgErr.Message, "update or delete"):
+			return fmt.Errorf("%w: %w", ErrStillReferenced, err)
+		case pgErr.Code == pgForeignKeyViolation:
+			return fmt.Errorf("%w: %w", ErrReferenceNotFound, err)
+		case pgErr.Code == pgInvalidTextRepresentation:
+			return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		case strings.HasPrefix(pgErr.Code, pgConnectionExceptionClass),
+			strings.HasPrefix(pgErr.Code, pgInsufficientResourceClass),
+			pgErr.Code == pgAdminShutdown, pgErr.Code == pgCrashShutdown, pgErr.Code == pgCannotConnectNow:
+			return fmt.Errorf("%w: %w", ErrDatabaseUnavailable, err)
+		}
+		return err
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr *net.OpError
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) || errors.Is(err, puddle.ErrClosedPool) {
+		return fmt.Errorf("%w: %w", ErrDatabaseUnavailable, err)
+	}
+	return err
+}
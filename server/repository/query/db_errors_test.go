@@ -0,0 +1,96 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/puddle/v2"
+)
+
+func TestDBError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *errs.Error
+		code errs.Code
+	}{
+		{
+			name: "unique violation",
+			err:  &pgconn.PgError{Code: "23505", Message: `duplicate key value violates unique constraint "users_email_key"`},
+			want: ErrAlreadyExists,
+			code: errs.CodeConflict,
+		},
+		{
+			name: "insert referring to a missing row",
+			err:  &pgconn.PgError{Code: "23503", Message: `insert or update on table "comments" violates foreign key constraint "comments_author_id_fkey"`},
+			want: ErrReferenceNotFound,
+			code: errs.CodeInvalidArgument,
+		},
+		{
+			name: "delete of a referred to row",
+			err:  &pgconn.PgError{Code: "23503", Message: `update or delete on table "projects" violates foreign key constraint "issues_project_id_fkey" on table "issues"`},
+			want: ErrStillReferenced,
+			code: errs.CodeConflict,
+		},
+		{
+			name: "malformed UUID",
+			err:  fmt.Errorf("failed to query Comments: %w", &pgconn.PgError{Code: "22P02", Message: `invalid input syntax for type uuid: "abc"`}),
+			want: ErrInvalidValue,
+			code: errs.CodeInvalidArgument,
+		},
+		{
+			name: "too many connections",
+			err:  &pgconn.PgError{Code: "53300", Message: "sorry, too many clients already"},
+			want: ErrDatabaseUnavailable,
+			code: errs.CodeUnavailable,
+		},
+		{
+			name: "server shutting down",
+			err:  &pgconn.PgError{Code: "57P01", Message: "terminating connection due to administrator command"},
+			want: ErrDatabaseUnavailable,
+			code: errs.CodeUnavailable,
+		},
+		{
+			name: "closed pool",
+			err:  puddle.ErrClosedPool,
+			want: ErrDatabaseUnavailable,
+			code: errs.CodeUnavailable,
+		},
+		{
+			name: "other database error",
+			err:  &pgconn.PgError{Code: "42P01", Message: `relation "issues" does not exist`},
+			code: errs.CodeInternal,
+		},
+		{
+			name: "domain error",
+			err:  ErrUserNotFound,
+			want: ErrUserNotFound,
+			code: errs.CodeNotFound,
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("failed to query Issues: %w", context.DeadlineExceeded),
+			code: errs.CodeDeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DBError(tt.err)
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v to wrap %v", err, tt.err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v to be %v", err, tt.want)
+			}
+			if code := errs.From(err).Code; code != tt.code {
+				t.Fatalf("expected %s, got %s", tt.code, code)
+			}
+		})
+	}
+}
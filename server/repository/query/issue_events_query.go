@@ -0,0 +1,210 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Operations recorded in the issue_events audit log.
+const (
+	IssueEventCreate     = "create"
+	IssueEventUpdate     = "update"
+	IssueEventDelete     = "delete"
+	IssueEventTransition = "transition"
+	IssueEventRestore    = "restore"
+	IssueEventPurge      = "purge"
+	// Labels and assignees are recorded as a change of label_id or
+	// assignee_id, from nothing when added and to nothing when removed.
+	IssueEventAddLabel    = "add_label"
+	IssueEventRemoveLabel = "remove_label"
+	IssueEventAssign      = "assign"
+	IssueEventUnassign    = "unassign"
+)
+
+type IssueEventQuery interface {
+	ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error)
+	CreateIssueEvent(ctx context.Context, tx pgx.Tx, event *api.IssueEvent) error
+}
+
+const issueEventColumns = `id, issue_id, project_id, actor_id, operation, changes, occurred_at`
+
+// fieldChange is how a FieldChange is stored in the changes JSONB column.
+type fieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before,omitempty"`
+	After  *string `json:"after,omitempty"`
+}
+
+type issueEventQuery struct {
+	db *pgxpool.Pool
+}
+
+func NewIssueEventQuery(db *pgxpool.Pool) IssueEventQuery {
+	return &issueEventQuery{
+		db: db,
+	}
+}
+
+// ListIssueEvents returns the audit trail of an issue, oldest first. Events
+// are matched on project as well as issue, and are kept after the issue itself
+// is gone, so no existence check is made.
+func (q *issueEventQuery) ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error) {
+	if req == nil || req.ProjectId == "" || req.IssueId == "" {
+		return nil, errs.InvalidArgument("Project ID and issue ID cannot be empty.")
+	}
+
+	after, err := decodeCursor(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && after.Rank != nil {
+		return nil, ErrInvalidPageToken
+	}
+	limit := pageSize(req.PageSize)
+
+	args := []any{req.IssueId, req.ProjectId}
+	conditions := "issue_id = $1 AND project_id = $2"
+	if after != nil {
+		afterID, err := strconv.ParseInt(after.ID, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		args = append(args, after.CreatedAt, afterID)
+		conditions += " AND (occurred_at, id) > ($3, $4)"
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`SELECT %s FROM issue_events WHERE %s ORDER BY occurred_at, id LIMIT $%d`, issueEventColumns, conditions, len(args))
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Issue events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*api.IssueEvent
+	for rows.Next() {
+		event, err := scanIssueEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextPageToken string
+	if len(events) > limit {
+		events = events[:limit]
+		last := events[limit-1]
+		nextPageToken = encodeCursor(cursor{CreatedAt: last.OccurredAt.AsTime(), ID: strconv.FormatInt(last.Id, 10)})
+	}
+
+	return &api.ListIssueEventsResponse{
+		Events:        events,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (q *issueEventQuery) CreateIssueEvent(ctx context.Context, tx pgx.Tx, event *api.IssueEvent) error {
+	return insertIssueEvent(ctx, tx, event)
+}
+
+// recordIssueChange records a change to data an issue is served with that
+// lives outside its own row, in the transaction making it.
+func recordIssueChange(ctx context.Context, tx pgx.Tx, operation string, actor string, projectID string, issueID string, change *api.FieldChange) error {
+	return insertIssueEvent(ctx, tx, &api.IssueEvent{
+		IssueId:    issueID,
+		ProjectId:  projectID,
+		ActorId:    actor,
+		Operation:  operation,
+		Changes:    []*api.FieldChange{change},
+		OccurredAt: timestamppb.New(time.Now()),
+	})
+}
+
+func insertIssueEvent(ctx context.Context, tx pgx.Tx, event *api.IssueEvent) error {
+	changes := make([]fieldChange, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, fieldChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO issue_events (issue_id, project_id, actor_id, operation, changes, occurred_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6)`
+
+	if _, err := tx.Exec(ctx, query, event.IssueId, event.ProjectId, event.ActorId, event.Operation, raw, event.OccurredAt.AsTime()); err != nil {
+		return fmt.Errorf("failed to record issue event: %w", err)
+	}
+	return nil
+}
+
+// IssueChanges diffs the audited fields of an issue. Either side may be nil,
+// for issues that are being created or deleted.
+func IssueChanges(before *api.Issue, after *api.Issue) []*api.FieldChange {
+	fields := []struct {
+		name  string
+		value func(*api.Issue) string
+	}{
+		{"title", func(issue *api.Issue) string { return issue.Title }},
+		{"description", func(issue *api.Issue) string { return issue.Description }},
+		{"status", func(issue *api.Issue) string { return IssueStatusName(issue.Status) }},
+		{"reporter_id", func(issue *api.Issue) string { return issue.ReporterId }},
+	}
+
+	var changes []*api.FieldChange
+	for _, field := range fields {
+		change := &api.FieldChange{Field: field.name}
+		if before != nil {
+			value := field.value(before)
+			change.Before = &value
+		}
+		if after != nil {
+			value := field.value(after)
+			change.After = &value
+		}
+		if change.Before != nil && change.After != nil && *change.Before == *change.After {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func scanIssueEvent(row pgx.Row) (*api.IssueEvent, error) {
+	var event api.IssueEvent
+	var actorID *string
+	var raw []byte
+	var occurredAt time.Time
+
+	if err := row.Scan(&event.Id, &event.IssueId, &event.ProjectId, &actorID, &event.Operation, &raw, &occurredAt); err != nil {
+		return nil, err
+	}
+	if actorID != nil {
+		event.ActorId = *actorID
+	}
+	event.OccurredAt = timestamppb.New(occurredAt)
+
+	var changes []fieldChange
+	if err := json.Unmarshal(raw, &changes); err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		event.Changes = append(event.Changes, &api.FieldChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+
+	return &event, nil
+}
@@ -4,19 +4,74 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/daffaromero/matesite/server/errs"
 	api "github.com/daffaromero/matesite/server/protobuf"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type IssueQuery interface {
 	GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error)
 	ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error)
+	ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error)
+	GetIssueForUpdate(ctx context.Context, tx pgx.Tx, projectID string, id string) (*api.Issue, error)
+	GetDeletedIssueForUpdate(ctx context.Context, tx pgx.Tx, projectID string, id string) (*api.Issue, error)
 	CreateIssue(ctx context.Context, tx pgx.Tx, req *api.CreateIssueRequest) (*api.CreateIssueResponse, error)
 	UpdateIssue(ctx context.Context, tx pgx.Tx, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error)
 	DeleteIssue(ctx context.Context, tx pgx.Tx, id *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error)
+	TransitionIssue(ctx context.Context, tx pgx.Tx, req *api.TransitionIssueRequest, from api.IssueStatus) (*api.TransitionIssueResponse, error)
+	RestoreIssue(ctx context.Context, tx pgx.Tx, req *api.RestoreIssueRequest) (*api.RestoreIssueResponse, error)
+	PurgeIssue(ctx context.Context, tx pgx.Tx, req *api.PurgeIssueRequest) (*api.PurgeIssueResponse, error)
+	PurgeExpiredIssues(ctx context.Context, tx pgx.Tx, deletedBefore time.Time, limit int) ([]*api.Issue, error)
+}
+
+// ErrVersionMismatch is returned when a write names a version of an issue
+// that is no longer its current one.
+var ErrVersionMismatch = errs.PreconditionFailed("Issue was modified since it was read, reload it and try again.")
+
+// issueUpdateFields maps the update mask paths UpdateIssue accepts to the
+// columns they set.
+var issueUpdateFields = map[string]struct {
+	column string
+	value  func(*api.Issue) any
+}{
+	"title":       {"title", func(issue *api.Issue) any { return issue.Title }},
+	"description": {"description", func(issue *api.Issue) any { return issue.Description }},
+}
+
+// IssueUpdatePaths returns the update mask paths an issue can be updated by.
+func IssueUpdatePaths() []string {
+	paths := make([]string, 0, len(issueUpdateFields))
+	for path := range issueUpdateFields {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+const (
+	issueColumns = `id, title, description, created_at, updated_at, status, status_changed_by, status_changed_at, reporter_id, project_id, deleted_at, version`
+
+	// ts_headline marks matches with control characters, which are removed
+	// from the text beforehand so that they cannot come from it. The snippet
+	// is HTML-escaped before they are turned into <mark> elements.
+	headlineStart   = "\x02"
+	headlineStop    = "\x03"
+	headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + `, MaxFragments=2, FragmentDelimiter=" … "`
+)
+
+var headlineMarks = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// highlightSnippet turns a ts_headline snippet into HTML: the text is
+// escaped, and matches are the only markup, as <mark> elements.
+func highlightSnippet(snippet string) string {
+	return headlineMarks.Replace(html.EscapeString(snippet))
 }
 
 type issueQuery struct {
@@ -30,116 +85,563 @@ func NewIssueQuery(db *pgxpool.Pool) IssueQuery {
 }
 
 func (q *issueQuery) GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error) {
-	if req == nil || req.Id == "" {
-		return nil, errors.New("issue ID cannot be empty")
+	if req == nil || req.Id == "" || req.ProjectId == "" {
+		return nil, errs.InvalidArgument("Project ID and issue ID cannot be empty.")
 	}
-	query := `SELECT id, title, description FROM issues WHERE id = $1 AND deleted_at IS NULL`
-
-	row := q.db.QueryRow(ctx, query, req.Id)
+	query := `SELECT ` + issueColumns + ` FROM issues WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL`
 
-	var issue api.Issue
-	err := row.Scan(&issue.Id, &issue.Title, &issue.Description)
+	issue, err := scanIssue(q.db.QueryRow(ctx, query, req.Id, req.ProjectId))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("issue with ID %s not found", req.Id)
+			return nil, errs.NotFound(fmt.Sprintf("Issue with ID %s not found.", req.Id))
 		}
 		return nil, err
 	}
 
+	if err := attachIssueDetails(ctx, q.db, []*api.Issue{issue}); err != nil {
+		return nil, err
+	}
+
 	return &api.GetIssueResponse{
-		Issue: &issue,
+		Issue: issue,
 	}, nil
 }
 
 func (q *issueQuery) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error) {
-	query := `SELECT id, title, description FROM issues WHERE deleted_at IS NULL`
+	if req == nil || req.ProjectId == "" {
+		return nil, errs.InvalidArgument("Project ID cannot be empty.")
+	}
 
-	rows, err := q.db.Query(ctx, query)
+	after, err := decodeCursor(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	limit := pageSize(req.PageSize)
+
+	// Every listing is confined to a single project; the other filters only
+	// ever narrow it down further.
+	filters := []string{"project_id = $1", "deleted_at IS NULL"}
+	args := []any{req.ProjectId}
+
+	search := strings.TrimSpace(req.Search)
+	var tsQuery string
+	if search != "" {
+		args = append(args, search)
+		tsQuery = fmt.Sprintf("websearch_to_tsquery('english', $%d)", len(args))
+		filters = append(filters, "search_vector @@ "+tsQuery)
+	}
+	if len(req.Statuses) > 0 {
+		statuses := make([]string, 0, len(req.Statuses))
+		for _, status := range req.Statuses {
+			statuses = append(statuses, IssueStatusName(status))
+		}
+		args = append(args, statuses)
+		filters = append(filters, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+	if labels := labelNames(req.Labels); len(labels) > 0 {
+		args = append(args, labels)
+		if req.LabelMatch == api.LabelMatch_LABEL_MATCH_ANY {
+			filters = append(filters, fmt.Sprintf(`EXISTS (SELECT 1 FROM issue_labels il JOIN labels l ON l.id = il.label_id
+				WHERE il.issue_id = issues.id AND lower(l.name) = ANY($%d))`, len(args)))
+		} else {
+			args = append(args, len(labels))
+			filters = append(filters, fmt.Sprintf(`id IN (SELECT il.issue_id FROM issue_labels il JOIN labels l ON l.id = il.label_id
+				WHERE lower(l.name) = ANY($%d) GROUP BY il.issue_id HAVING COUNT(*) = $%d)`, len(args)-1, len(args)))
+		}
+	}
+	if req.Assignee != "" {
+		args = append(args, req.Assignee)
+		filters = append(filters, fmt.Sprintf("EXISTS (SELECT 1 FROM issue_assignees ia WHERE ia.issue_id = issues.id AND ia.user_id = $%d::uuid)", len(args)))
+	}
+	if req.Reporter != "" {
+		args = append(args, req.Reporter)
+		filters = append(filters, fmt.Sprintf("reporter_id = $%d::uuid", len(args)))
+	}
+	if (after != nil && after.Rank != nil) != (search != "") {
+		return nil, ErrInvalidPageToken
+	}
+
+	var total int64
+	if req.IncludeTotal {
+		countQuery := `SELECT COUNT(*) FROM issues WHERE ` + strings.Join(filters, " AND ")
+		if err := q.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("failed to count Issues: %w", err)
+		}
+	}
+
+	columns := issueColumns
+	keyset := "created_at, id"
+	order := "created_at DESC, id DESC"
+	if search != "" {
+		rank := fmt.Sprintf("ts_rank_cd(search_vector, %s)::real", tsQuery)
+		args = append(args, headlineOptions)
+		options := fmt.Sprintf("$%d", len(args))
+		columns += fmt.Sprintf(`, %s, ts_headline('english', translate(title, E'\x02\x03', ''), %s, %s), ts_headline('english', translate(coalesce(description, ''), E'\x02\x03', ''), %s, %s)`,
+			rank, tsQuery, options, tsQuery, options)
+		keyset = rank + ", " + keyset
+		order = rank + " DESC, " + order
+	}
+
+	conditions := append([]string{}, filters...)
+	if after != nil {
+		var bounds []string
+		if after.Rank != nil {
+			args = append(args, *after.Rank)
+			bounds = append(bounds, fmt.Sprintf("$%d::real", len(args)))
+		}
+		args = append(args, after.CreatedAt, after.ID)
+		bounds = append(bounds, fmt.Sprintf("$%d", len(args)-1), fmt.Sprintf("$%d::uuid", len(args)))
+		conditions = append(conditions, fmt.Sprintf("(%s) < (%s)", keyset, strings.Join(bounds, ", ")))
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`SELECT %s FROM issues WHERE %s ORDER BY %s LIMIT $%d`,
+		columns, strings.Join(conditions, " AND "), order, len(args))
+
+	rows, err := q.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query Issues: %w", err)
 	}
 	defer rows.Close()
 
 	var Issues []*api.Issue
+	var matches []*api.SearchMatch
 	for rows.Next() {
-		var issue api.Issue
-		err := rows.Scan(&issue.Id, &issue.Title, &issue.Description)
+		var match api.SearchMatch
+		var extra []any
+		if search != "" {
+			extra = []any{&match.Score, &match.TitleSnippet, &match.DescriptionSnippet}
+		}
+
+		issue, err := scanIssue(rows, extra...)
 		if err != nil {
 			return nil, err
 		}
-		Issues = append(Issues, &issue)
+		Issues = append(Issues, issue)
+
+		if search != "" {
+			match.IssueId = issue.Id
+			match.TitleSnippet = highlightSnippet(match.TitleSnippet)
+			match.DescriptionSnippet = highlightSnippet(match.DescriptionSnippet)
+			matches = append(matches, &match)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	var nextPageToken string
+	if len(Issues) > limit {
+		Issues = Issues[:limit]
+		last := Issues[limit-1]
+		next := cursor{CreatedAt: last.CreatedAt.AsTime(), ID: last.Id}
+		if search != "" {
+			matches = matches[:limit]
+			next.Rank = &matches[limit-1].Score
+		}
+		nextPageToken = encodeCursor(next)
+	}
+
+	if err := attachIssueDetails(ctx, q.db, Issues); err != nil {
+		return nil, err
 	}
 
 	return &api.ListIssuesResponse{
-		Issues: Issues,
+		Issues:        Issues,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
+		Matches:       matches,
+	}, nil
+}
+
+// ListDeletedIssues lists the trash of a project, most recently deleted first.
+func (q *issueQuery) ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error) {
+	if req == nil || req.ProjectId == "" {
+		return nil, errs.InvalidArgument("Project ID cannot be empty.")
+	}
+
+	after, err := decodeCursor(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && after.Rank != nil {
+		return nil, ErrInvalidPageToken
+	}
+	limit := pageSize(req.PageSize)
+
+	args := []any{req.ProjectId}
+	conditions := "project_id = $1 AND deleted_at IS NOT NULL"
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions += " AND (deleted_at, id) < ($2, $3::uuid)"
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`SELECT %s FROM issues WHERE %s ORDER BY deleted_at DESC, id DESC LIMIT $%d`, issueColumns, conditions, len(args))
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted Issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*api.Issue
+	for rows.Next() {
+		issue, err := scanIssue(rows)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	var nextPageToken string
+	if len(issues) > limit {
+		issues = issues[:limit]
+		last := issues[limit-1]
+		nextPageToken = encodeCursor(cursor{CreatedAt: last.DeletedAt.AsTime(), ID: last.Id})
+	}
+
+	if err := attachIssueDetails(ctx, q.db, issues); err != nil {
+		return nil, err
+	}
+
+	return &api.ListDeletedIssuesResponse{
+		Issues:        issues,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
+// GetIssueForUpdate loads a live issue and locks its row until the
+// transaction ends, so the state it returns is the one a write replaces.
+func (q *issueQuery) GetIssueForUpdate(ctx context.Context, tx pgx.Tx, projectID string, id string) (*api.Issue, error) {
+	query := `SELECT ` + issueColumns + ` FROM issues WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL FOR UPDATE`
+
+	issue, err := scanIssue(tx.QueryRow(ctx, query, id, projectID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Issue with ID %s not found.", id))
+		}
+		return nil, err
+	}
+	return issue, nil
+}
+
+// GetDeletedIssueForUpdate is GetIssueForUpdate for an issue in the trash.
+func (q *issueQuery) GetDeletedIssueForUpdate(ctx context.Context, tx pgx.Tx, projectID string, id string) (*api.Issue, error) {
+	query := `SELECT ` + issueColumns + ` FROM issues WHERE id = $1 AND project_id = $2 AND deleted_at IS NOT NULL FOR UPDATE`
+
+	issue, err := scanIssue(tx.QueryRow(ctx, query, id, projectID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Deleted issue with ID %s not found.", id))
+		}
+		return nil, err
+	}
+	return issue, nil
+}
+
 func (q *issueQuery) CreateIssue(ctx context.Context, tx pgx.Tx, req *api.CreateIssueRequest) (*api.CreateIssueResponse, error) {
 	if req == nil || req.Issue == nil {
-		return nil, errors.New("please provide a valid issue")
+		return nil, errs.InvalidArgument("Please provide a valid issue.")
 	}
-	query := `INSERT INTO issues (id, title, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, title, description`
+	query := `INSERT INTO issues (id, title, description, created_at, updated_at, status, reporter_id, project_id) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8) RETURNING ` + issueColumns
 
 	createdAt := req.Issue.CreatedAt.AsTime()
 	updatedAt := req.Issue.UpdatedAt.AsTime()
 
-	var createdIssue api.Issue
-
-	err := tx.QueryRow(ctx, query, req.Issue.Id, req.Issue.Title, req.Issue.Description, createdAt, updatedAt).Scan(&createdIssue.Id, &createdIssue.Title, &createdIssue.Description)
+	createdIssue, err := scanIssue(tx.QueryRow(ctx, query, req.Issue.Id, req.Issue.Title, req.Issue.Description, createdAt, updatedAt, IssueStatusName(req.Issue.Status), req.Issue.ReporterId, req.Issue.ProjectId))
 	if err != nil {
 		return nil, err
 	}
 
 	return &api.CreateIssueResponse{
-		Issue: &createdIssue,
+		Issue: createdIssue,
 	}, nil
 }
 
+// UpdateIssue sets the fields named by the update mask of req, and only
+// those, to their values in req.Issue.
 func (q *issueQuery) UpdateIssue(ctx context.Context, tx pgx.Tx, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error) {
 	if req == nil || req.Issue == nil {
-		return nil, errors.New("issue cannot be empty")
+		return nil, errs.InvalidArgument("Issue cannot be empty.")
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, errs.InvalidArgument("Update mask cannot be empty.")
 	}
-	query := `UPDATE issues 
-		SET 
-			title = COALESCE($2, title), 
-			description = COALESCE($3, description), 
-			updated_at = $4
-		WHERE id = $1 AND deleted_at IS NULL RETURNING id, title, description`
 
 	updatedAt := time.Now()
 	if req.Issue.UpdatedAt != nil {
 		updatedAt = req.Issue.UpdatedAt.AsTime()
 	}
 
-	var updatedIssue api.Issue
+	args := []any{req.Issue.Id, req.Issue.ProjectId, req.ExpectedVersion, updatedAt}
+	assignments := []string{"updated_at = $4", "version = version + 1"}
+	for _, path := range req.UpdateMask.GetPaths() {
+		field, ok := issueUpdateFields[path]
+		if !ok {
+			return nil, errs.InvalidField(path, fmt.Sprintf("Field %s cannot be updated.", path))
+		}
+		args = append(args, field.value(req.Issue))
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", field.column, len(args)))
+	}
+
+	query := fmt.Sprintf(`UPDATE issues SET %s
+		WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL AND version = $3 RETURNING %s`,
+		strings.Join(assignments, ", "), issueColumns)
 
-	err := tx.QueryRow(ctx, query, req.Issue.Id, req.Issue.Title, req.Issue.Description, updatedAt).Scan(&updatedIssue.Id, &updatedIssue.Title, &updatedIssue.Description)
+	updatedIssue, err := scanIssue(tx.QueryRow(ctx, query, args...))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, staleOrMissing(ctx, tx, req.Issue.ProjectId, req.Issue.Id)
+		}
+		return nil, err
+	}
+
+	if err := attachIssueDetails(ctx, tx, []*api.Issue{updatedIssue}); err != nil {
 		return nil, err
 	}
 
 	return &api.UpdateIssueResponse{
-		Issue: &updatedIssue,
+		Issue: updatedIssue,
 	}, nil
 }
 
 func (q *issueQuery) DeleteIssue(ctx context.Context, tx pgx.Tx, req *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error) {
-	if req.Id == "" {
-		return nil, errors.New("issue ID cannot be empty")
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID cannot be empty.")
 	}
 
-	query := `UPDATE issues SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	query := `UPDATE issues SET deleted_at = $2, version = version + 1
+		WHERE id = $1 AND project_id = $3 AND deleted_at IS NULL AND version = $4`
 
-	_, err := tx.Exec(ctx, query, req.Id, time.Now())
+	tag, err := tx.Exec(ctx, query, req.Id, time.Now(), req.ProjectId, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, staleOrMissing(ctx, tx, req.ProjectId, req.Id)
+	}
+
+	return &api.DeleteIssueResponse{
+		Success: true,
+	}, nil
+}
+
+func (q *issueQuery) TransitionIssue(ctx context.Context, tx pgx.Tx, req *api.TransitionIssueRequest, from api.IssueStatus) (*api.TransitionIssueResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID cannot be empty.")
+	}
+
+	query := `UPDATE issues
+		SET
+			status = $2,
+			status_changed_by = NULLIF($3, ''),
+			status_changed_at = $4,
+			updated_at = $4,
+			version = version + 1
+		WHERE id = $1 AND status = $5 AND project_id = $6 AND deleted_at IS NULL RETURNING ` + issueColumns
+
+	changedAt := time.Now()
+
+	issue, err := scanIssue(tx.QueryRow(ctx, query, req.Id, IssueStatusName(req.Status), req.ChangedBy, changedAt, IssueStatusName(from), req.ProjectId))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("issue with ID %s not found", req.Id)
+			// The issue is gone, or its status has moved on since it was read.
+			if err := issueExists(ctx, tx, req.ProjectId, req.Id); err != nil {
+				return nil, err
+			}
+			return nil, ErrStatusChanged
 		}
 		return nil, err
 	}
 
-	return &api.DeleteIssueResponse{
+	if err := attachIssueDetails(ctx, tx, []*api.Issue{issue}); err != nil {
+		return nil, err
+	}
+
+	insert := `INSERT INTO issue_status_transitions (issue_id, from_status, to_status, changed_by, changed_at) VALUES ($1, $2, $3, NULLIF($4, ''), $5)`
+
+	if _, err := tx.Exec(ctx, insert, req.Id, IssueStatusName(from), IssueStatusName(req.Status), req.ChangedBy, changedAt); err != nil {
+		return nil, err
+	}
+
+	return &api.TransitionIssueResponse{
+		Issue: issue,
+	}, nil
+}
+
+func (q *issueQuery) RestoreIssue(ctx context.Context, tx pgx.Tx, req *api.RestoreIssueRequest) (*api.RestoreIssueResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID cannot be empty.")
+	}
+
+	query := `UPDATE issues SET deleted_at = NULL, updated_at = $3, version = version + 1
+		WHERE id = $1 AND project_id = $2 AND deleted_at IS NOT NULL RETURNING ` + issueColumns
+
+	issue, err := scanIssue(tx.QueryRow(ctx, query, req.Id, req.ProjectId, time.Now()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Deleted issue with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	if err := attachIssueDetails(ctx, tx, []*api.Issue{issue}); err != nil {
+		return nil, err
+	}
+
+	return &api.RestoreIssueResponse{
+		Issue: issue,
+	}, nil
+}
+
+// PurgeIssue permanently deletes an issue that is in the trash. Its labels,
+// assignees, comments and transitions go with it through ON DELETE CASCADE.
+func (q *issueQuery) PurgeIssue(ctx context.Context, tx pgx.Tx, req *api.PurgeIssueRequest) (*api.PurgeIssueResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Issue ID cannot be empty.")
+	}
+
+	query := `DELETE FROM issues WHERE id = $1 AND project_id = $2 AND deleted_at IS NOT NULL`
+
+	tag, err := tx.Exec(ctx, query, req.Id, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("Deleted issue with ID %s not found.", req.Id))
+	}
+
+	return &api.PurgeIssueResponse{
 		Success: true,
 	}, nil
 }
+
+// PurgeExpiredIssues permanently deletes up to limit issues that were put in
+// the trash before deletedBefore, and returns them. Rows locked by another
+// transaction are skipped, so concurrent purges never wait on each other.
+func (q *issueQuery) PurgeExpiredIssues(ctx context.Context, tx pgx.Tx, deletedBefore time.Time, limit int) ([]*api.Issue, error) {
+	query := `DELETE FROM issues WHERE id IN (
+			SELECT id FROM issues WHERE deleted_at < $1 ORDER BY deleted_at LIMIT $2 FOR UPDATE SKIP LOCKED
+		) RETURNING ` + issueColumns
+
+	rows, err := tx.Query(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge expired Issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*api.Issue
+	for rows.Next() {
+		issue, err := scanIssue(rows)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// staleOrMissing explains why a versioned write matched no rows: either the
+// issue does not exist, or its version is not the expected one.
+func staleOrMissing(ctx context.Context, tx pgx.Tx, projectID string, id string) error {
+	if err := issueExists(ctx, tx, projectID, id); err != nil {
+		return err
+	}
+	return ErrVersionMismatch
+}
+
+// bumpIssueVersion moves an issue to a new version after a change to data it
+// is served with that lives outside its own row, such as labels and assignees.
+func bumpIssueVersion(ctx context.Context, tx pgx.Tx, issueID string) error {
+	query := `UPDATE issues SET version = version + 1 WHERE id = $1`
+	_, err := tx.Exec(ctx, query, issueID)
+	return err
+}
+
+// attachIssueDetails fills in the labels and assignees of the given issues,
+// which live in their own tables.
+func attachIssueDetails(ctx context.Context, db querier, issues []*api.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.Id)
+	}
+
+	labels, err := labelsByIssue(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+	assignees, err := assigneesByIssue(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.Id]
+		issue.AssigneeIds = assignees[issue.Id]
+	}
+	return nil
+}
+
+// labelNames normalises label filters to the lowercase, de-duplicated names
+// the labels_name_key index compares against.
+func labelNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	var normalised []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalised = append(normalised, name)
+	}
+	return normalised
+}
+
+// scanIssue reads the issueColumns of a row, followed by any extra columns
+// the caller selected.
+func scanIssue(row pgx.Row, extra ...any) (*api.Issue, error) {
+	var issue api.Issue
+	var createdAt, updatedAt, statusChangedAt, deletedAt *time.Time
+	var status string
+	var statusChangedBy, reporterID *string
+
+	dest := append([]any{&issue.Id, &issue.Title, &issue.Description, &createdAt, &updatedAt, &status, &statusChangedBy, &statusChangedAt, &reporterID, &issue.ProjectId, &deletedAt, &issue.Version}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	issue.Status, _ = ParseIssueStatus(status)
+	if statusChangedBy != nil {
+		issue.StatusChangedBy = *statusChangedBy
+	}
+	if statusChangedAt != nil {
+		issue.StatusChangedAt = timestamppb.New(*statusChangedAt)
+	}
+	if reporterID != nil {
+		issue.ReporterId = *reporterID
+	}
+	if createdAt != nil {
+		issue.CreatedAt = timestamppb.New(*createdAt)
+	}
+	if updatedAt != nil {
+		issue.UpdatedAt = timestamppb.New(*updatedAt)
+	}
+	if deletedAt != nil {
+		issue.DeletedAt = timestamppb.New(*deletedAt)
+	}
+
+	return &issue, nil
+}
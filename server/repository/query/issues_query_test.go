@@ -0,0 +1,35 @@
+package query
+
+import "testing"
+
+func TestHighlightSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{
+			name:    "plain text",
+			snippet: "Login fails on " + headlineStart + "Safari" + headlineStop,
+			want:    "Login fails on <mark>Safari</mark>",
+		},
+		{
+			name:    "markup in the text",
+			snippet: `<img src=x onerror="alert(1)"> ` + headlineStart + "crash" + headlineStop + " & <mark>",
+			want:    `&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>crash</mark> &amp; &lt;mark&gt;`,
+		},
+		{
+			name:    "fragments",
+			snippet: headlineStart + "a" + headlineStop + " … '" + headlineStart + "b" + headlineStop + "'",
+			want:    "<mark>a</mark> … &#39;<mark>b</mark>&#39;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightSnippet(tt.snippet); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
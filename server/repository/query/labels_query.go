@@ -0,0 +1,294 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type LabelQuery interface {
+	GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error)
+	ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error)
+	CreateLabel(ctx context.Context, tx pgx.Tx, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error)
+	UpdateLabel(ctx context.Context, tx pgx.Tx, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error)
+	DeleteLabel(ctx context.Context, tx pgx.Tx, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error)
+	AddIssueLabel(ctx context.Context, tx pgx.Tx, req *api.AddIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error)
+	RemoveIssueLabel(ctx context.Context, tx pgx.Tx, req *api.RemoveIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error)
+}
+
+const labelColumns = `id, name, color, description, created_at, updated_at`
+
+type labelQuery struct {
+	db *pgxpool.Pool
+}
+
+func NewLabelQuery(db *pgxpool.Pool) LabelQuery {
+	return &labelQuery{
+		db: db,
+	}
+}
+
+func (q *labelQuery) GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Label ID cannot be empty.")
+	}
+	query := `SELECT ` + labelColumns + ` FROM labels WHERE id = $1`
+
+	label, err := scanLabel(q.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Label with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	return &api.GetLabelResponse{
+		Label: label,
+	}, nil
+}
+
+func (q *labelQuery) ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels ORDER BY lower(name)`
+
+	rows, err := q.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []*api.Label
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.ListLabelsResponse{
+		Labels: labels,
+	}, nil
+}
+
+func (q *labelQuery) CreateLabel(ctx context.Context, tx pgx.Tx, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error) {
+	if req == nil || req.Label == nil {
+		return nil, errs.InvalidArgument("Please provide a valid label.")
+	}
+	query := `INSERT INTO labels (id, name, color, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + labelColumns
+
+	createdAt := req.Label.CreatedAt.AsTime()
+	updatedAt := req.Label.UpdatedAt.AsTime()
+
+	label, err := scanLabel(tx.QueryRow(ctx, query, req.Label.Id, req.Label.Name, req.Label.Color, req.Label.Description, createdAt, updatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateLabelResponse{
+		Label: label,
+	}, nil
+}
+
+func (q *labelQuery) UpdateLabel(ctx context.Context, tx pgx.Tx, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error) {
+	if req == nil || req.Label == nil {
+		return nil, errs.InvalidArgument("Label cannot be empty.")
+	}
+	query := `UPDATE labels
+		SET
+			name = COALESCE(NULLIF($2, ''), name),
+			color = COALESCE(NULLIF($3, ''), color),
+			description = COALESCE(NULLIF($4, ''), description),
+			updated_at = $5
+		WHERE id = $1 RETURNING ` + labelColumns
+
+	updatedAt := time.Now()
+	if req.Label.UpdatedAt != nil {
+		updatedAt = req.Label.UpdatedAt.AsTime()
+	}
+
+	label, err := scanLabel(tx.QueryRow(ctx, query, req.Label.Id, req.Label.Name, req.Label.Color, req.Label.Description, updatedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Label with ID %s not found.", req.Label.Id))
+		}
+		return nil, err
+	}
+
+	return &api.UpdateLabelResponse{
+		Label: label,
+	}, nil
+}
+
+func (q *labelQuery) DeleteLabel(ctx context.Context, tx pgx.Tx, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error) {
+	if req.Id == "" {
+		return nil, errs.InvalidArgument("Label ID cannot be empty.")
+	}
+
+	query := `DELETE FROM labels WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("Label with ID %s not found.", req.Id))
+	}
+
+	return &api.DeleteLabelResponse{
+		Success: true,
+	}, nil
+}
+
+func (q *labelQuery) AddIssueLabel(ctx context.Context, tx pgx.Tx, req *api.AddIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error) {
+	if req.IssueId == "" || req.LabelId == "" {
+		return nil, errs.InvalidArgument("Issue ID and label ID cannot be empty.")
+	}
+	if err := q.checkIssueLabelRefs(ctx, tx, req.ProjectId, req.IssueId, req.LabelId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO issue_labels (issue_id, label_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	tag, err := tx.Exec(ctx, query, req.IssueId, req.LabelId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpIssueVersion(ctx, tx, req.IssueId); err != nil {
+			return nil, err
+		}
+		change := &api.FieldChange{Field: "label_id", After: &req.LabelId}
+		if err := recordIssueChange(ctx, tx, IssueEventAddLabel, actor, req.ProjectId, req.IssueId, change); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.issueLabels(ctx, tx, req.IssueId)
+}
+
+func (q *labelQuery) RemoveIssueLabel(ctx context.Context, tx pgx.Tx, req *api.RemoveIssueLabelRequest, actor string) (*api.IssueLabelsResponse, error) {
+	if req.IssueId == "" || req.LabelId == "" {
+		return nil, errs.InvalidArgument("Issue ID and label ID cannot be empty.")
+	}
+	if err := q.checkIssueLabelRefs(ctx, tx, req.ProjectId, req.IssueId, req.LabelId); err != nil {
+		return nil, err
+	}
+
+	query := `DELETE FROM issue_labels WHERE issue_id = $1 AND label_id = $2`
+
+	tag, err := tx.Exec(ctx, query, req.IssueId, req.LabelId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpIssueVersion(ctx, tx, req.IssueId); err != nil {
+			return nil, err
+		}
+		change := &api.FieldChange{Field: "label_id", Before: &req.LabelId}
+		if err := recordIssueChange(ctx, tx, IssueEventRemoveLabel, actor, req.ProjectId, req.IssueId, change); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.issueLabels(ctx, tx, req.IssueId)
+}
+
+func (q *labelQuery) checkIssueLabelRefs(ctx context.Context, tx pgx.Tx, projectID string, issueID string, labelID string) error {
+	query := `SELECT
+			EXISTS (SELECT 1 FROM issues WHERE id = $1 AND project_id = $3 AND deleted_at IS NULL),
+			EXISTS (SELECT 1 FROM labels WHERE id = $2)`
+
+	var issueExists, labelExists bool
+	if err := tx.QueryRow(ctx, query, issueID, labelID, projectID).Scan(&issueExists, &labelExists); err != nil {
+		return err
+	}
+	if !issueExists {
+		return errs.NotFound(fmt.Sprintf("Issue with ID %s not found.", issueID))
+	}
+	if !labelExists {
+		return errs.NotFound(fmt.Sprintf("Label with ID %s not found.", labelID))
+	}
+	return nil
+}
+
+func (q *labelQuery) issueLabels(ctx context.Context, tx pgx.Tx, issueID string) (*api.IssueLabelsResponse, error) {
+	labels, err := labelsByIssue(ctx, tx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.IssueLabelsResponse{
+		IssueId: issueID,
+		Labels:  labels[issueID],
+	}, nil
+}
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// labelsByIssue loads the labels of every given issue in a single query,
+// keyed by issue ID.
+func labelsByIssue(ctx context.Context, db querier, issueIDs []string) (map[string][]*api.Label, error) {
+	query := `SELECT il.issue_id, l.id, l.name, l.color, l.description, l.created_at, l.updated_at
+		FROM issue_labels il JOIN labels l ON l.id = il.label_id
+		WHERE il.issue_id = ANY($1::uuid[])
+		ORDER BY lower(l.name)`
+
+	rows, err := db.Query(ctx, query, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := make(map[string][]*api.Label, len(issueIDs))
+	for rows.Next() {
+		var issueID string
+		label, err := scanLabel(rows, &issueID)
+		if err != nil {
+			return nil, err
+		}
+		labels[issueID] = append(labels[issueID], label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// scanLabel reads the labelColumns of a row, preceded by any leading columns
+// the caller selected.
+func scanLabel(row pgx.Row, leading ...any) (*api.Label, error) {
+	var label api.Label
+	var description *string
+	var createdAt, updatedAt *time.Time
+
+	dest := append(leading, &label.Id, &label.Name, &label.Color, &description, &createdAt, &updatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if description != nil {
+		label.Description = *description
+	}
+	if createdAt != nil {
+		label.CreatedAt = timestamppb.New(*createdAt)
+	}
+	if updatedAt != nil {
+		label.UpdatedAt = timestamppb.New(*updatedAt)
+	}
+
+	return &label, nil
+}
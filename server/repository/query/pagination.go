@@ -0,0 +1,59 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+var ErrInvalidPageToken = errs.InvalidField("page_token", "Invalid page token.")
+
+// cursor is the keyset position a page token points at. Rows are ordered by
+// (created_at, id) descending, so the next page starts strictly after it.
+// Search results are ordered by relevance first, so their cursors also carry
+// the rank of the last row.
+type cursor struct {
+	Rank      *float32  `json:"r,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &c, nil
+}
+
+func pageSize(size int32) int {
+	switch {
+	case size <= 0:
+		return DefaultPageSize
+	case size > MaxPageSize:
+		return MaxPageSize
+	}
+	return int(size)
+}
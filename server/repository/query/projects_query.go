@@ -0,0 +1,294 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrNotProjectMember is returned by GetProjectRole for users that are not
+// members of the project, including when the project does not exist.
+var ErrNotProjectMember = errs.NotFound("User is not a member of the project.")
+
+type ProjectQuery interface {
+	GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error)
+	ListProjects(ctx context.Context, req *api.ListProjectsRequest, memberID string) (*api.ListProjectsResponse, error)
+	GetProjectRole(ctx context.Context, projectID string, userID string) (string, error)
+	CreateProject(ctx context.Context, tx pgx.Tx, req *api.CreateProjectRequest, ownerID string) (*api.CreateProjectResponse, error)
+	UpdateProject(ctx context.Context, tx pgx.Tx, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error)
+	DeleteProject(ctx context.Context, tx pgx.Tx, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error)
+	ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error)
+	SetProjectMember(ctx context.Context, tx pgx.Tx, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error)
+	RemoveProjectMember(ctx context.Context, tx pgx.Tx, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error)
+}
+
+const (
+	projectColumns       = `id, name, description, created_at, updated_at`
+	projectMemberColumns = `project_id, user_id, role, created_at`
+)
+
+type projectQuery struct {
+	db *pgxpool.Pool
+}
+
+func NewProjectQuery(db *pgxpool.Pool) ProjectQuery {
+	return &projectQuery{
+		db: db,
+	}
+}
+
+func (q *projectQuery) GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("Project ID cannot be empty.")
+	}
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
+
+	project, err := scanProject(q.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Project with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	return &api.GetProjectResponse{
+		Project: project,
+	}, nil
+}
+
+// ListProjects lists every project, or only those memberID belongs to when it
+// is set.
+func (q *projectQuery) ListProjects(ctx context.Context, req *api.ListProjectsRequest, memberID string) (*api.ListProjectsResponse, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects
+		WHERE $1 = '' OR id IN (SELECT project_id FROM project_members WHERE user_id = NULLIF($1, '')::uuid)
+		ORDER BY lower(name)`
+
+	rows, err := q.db.Query(ctx, query, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*api.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.ListProjectsResponse{
+		Projects: projects,
+	}, nil
+}
+
+func (q *projectQuery) GetProjectRole(ctx context.Context, projectID string, userID string) (string, error) {
+	var role string
+	query := `SELECT role FROM project_members WHERE project_id = $1 AND user_id = $2`
+	if err := q.db.QueryRow(ctx, query, projectID, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotProjectMember
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+// CreateProject inserts the project and makes ownerID its first maintainer.
+func (q *projectQuery) CreateProject(ctx context.Context, tx pgx.Tx, req *api.CreateProjectRequest, ownerID string) (*api.CreateProjectResponse, error) {
+	if req == nil || req.Project == nil {
+		return nil, errs.InvalidArgument("Please provide a valid project.")
+	}
+	query := `INSERT INTO projects (id, name, description, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING ` + projectColumns
+
+	createdAt := req.Project.CreatedAt.AsTime()
+	updatedAt := req.Project.UpdatedAt.AsTime()
+
+	project, err := scanProject(tx.QueryRow(ctx, query, req.Project.Id, req.Project.Name, req.Project.Description, createdAt, updatedAt))
+	if err != nil {
+		return nil, err
+	}
+
+	member := `INSERT INTO project_members (project_id, user_id, role, created_at) VALUES ($1, $2, 'maintainer', $3)`
+	if _, err := tx.Exec(ctx, member, project.Id, ownerID, createdAt); err != nil {
+		return nil, err
+	}
+
+	return &api.CreateProjectResponse{
+		Project: project,
+	}, nil
+}
+
+func (q *projectQuery) UpdateProject(ctx context.Context, tx pgx.Tx, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error) {
+	if req == nil || req.Project == nil {
+		return nil, errs.InvalidArgument("Project cannot be empty.")
+	}
+	query := `UPDATE projects
+		SET
+			name = COALESCE(NULLIF($2, ''), name),
+			description = COALESCE(NULLIF($3, ''), description),
+			updated_at = $4
+		WHERE id = $1 RETURNING ` + projectColumns
+
+	updatedAt := time.Now()
+	if req.Project.UpdatedAt != nil {
+		updatedAt = req.Project.UpdatedAt.AsTime()
+	}
+
+	project, err := scanProject(tx.QueryRow(ctx, query, req.Project.Id, req.Project.Name, req.Project.Description, updatedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("Project with ID %s not found.", req.Project.Id))
+		}
+		return nil, err
+	}
+
+	return &api.UpdateProjectResponse{
+		Project: project,
+	}, nil
+}
+
+func (q *projectQuery) DeleteProject(ctx context.Context, tx pgx.Tx, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error) {
+	if req.Id == "" {
+		return nil, errs.InvalidArgument("Project ID cannot be empty.")
+	}
+
+	query := `DELETE FROM projects WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("Project with ID %s not found.", req.Id))
+	}
+
+	return &api.DeleteProjectResponse{
+		Success: true,
+	}, nil
+}
+
+func (q *projectQuery) ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error) {
+	if req == nil || req.ProjectId == "" {
+		return nil, errs.InvalidArgument("Project ID cannot be empty.")
+	}
+	query := `SELECT ` + projectMemberColumns + ` FROM project_members WHERE project_id = $1 ORDER BY created_at, user_id`
+
+	rows, err := q.db.Query(ctx, query, req.ProjectId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Project members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*api.ProjectMember
+	for rows.Next() {
+		member, err := scanProjectMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.ListProjectMembersResponse{
+		Members: members,
+	}, nil
+}
+
+// SetProjectMember adds a user to a project, or changes the role of an
+// existing member.
+func (q *projectQuery) SetProjectMember(ctx context.Context, tx pgx.Tx, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error) {
+	if req.ProjectId == "" || req.UserId == "" || req.Role == "" {
+		return nil, errs.InvalidArgument("Project ID, user ID and role cannot be empty.")
+	}
+
+	var userExists bool
+	check := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`
+	if err := tx.QueryRow(ctx, check, req.UserId).Scan(&userExists); err != nil {
+		return nil, err
+	}
+	if !userExists {
+		return nil, errs.NotFound(fmt.Sprintf("User with ID %s not found.", req.UserId))
+	}
+
+	query := `INSERT INTO project_members (project_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		RETURNING ` + projectMemberColumns
+
+	member, err := scanProjectMember(tx.QueryRow(ctx, query, req.ProjectId, req.UserId, req.Role, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SetProjectMemberResponse{
+		Member: member,
+	}, nil
+}
+
+func (q *projectQuery) RemoveProjectMember(ctx context.Context, tx pgx.Tx, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error) {
+	if req.ProjectId == "" || req.UserId == "" {
+		return nil, errs.InvalidArgument("Project ID and user ID cannot be empty.")
+	}
+
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+
+	tag, err := tx.Exec(ctx, query, req.ProjectId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("Project member with ID %s not found.", req.UserId))
+	}
+
+	return &api.RemoveProjectMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func scanProject(row pgx.Row) (*api.Project, error) {
+	var project api.Project
+	var description *string
+	var createdAt, updatedAt *time.Time
+
+	if err := row.Scan(&project.Id, &project.Name, &description, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if description != nil {
+		project.Description = *description
+	}
+	if createdAt != nil {
+		project.CreatedAt = timestamppb.New(*createdAt)
+	}
+	if updatedAt != nil {
+		project.UpdatedAt = timestamppb.New(*updatedAt)
+	}
+
+	return &project, nil
+}
+
+func scanProjectMember(row pgx.Row) (*api.ProjectMember, error) {
+	var member api.ProjectMember
+	var createdAt *time.Time
+
+	if err := row.Scan(&member.ProjectId, &member.UserId, &member.Role, &createdAt); err != nil {
+		return nil, err
+	}
+	if createdAt != nil {
+		member.CreatedAt = timestamppb.New(*createdAt)
+	}
+
+	return &member, nil
+}
@@ -0,0 +1,29 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+)
+
+const issueStatusPrefix = "ISSUE_STATUS_"
+
+// ErrStatusChanged is returned when an issue's status no longer matches the
+// status a transition was validated against.
+var ErrStatusChanged = errs.Conflict("Issue status was changed concurrently, please retry.")
+
+// IssueStatusName returns the lowercase name an IssueStatus is stored and
+// exposed as, e.g. "in_progress".
+func IssueStatusName(status api.IssueStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), issueStatusPrefix))
+}
+
+func ParseIssueStatus(name string) (api.IssueStatus, error) {
+	value, ok := api.IssueStatus_value[issueStatusPrefix+strings.ToUpper(strings.TrimSpace(name))]
+	if !ok || value == int32(api.IssueStatus_ISSUE_STATUS_UNSPECIFIED) {
+		return api.IssueStatus_ISSUE_STATUS_UNSPECIFIED, errs.InvalidField("status", fmt.Sprintf("Invalid issue status %q.", name))
+	}
+	return api.IssueStatus(value), nil
+}
@@ -0,0 +1,267 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrUserNotFound is returned by GetUserRole for users without a record.
+var ErrUserNotFound = errs.NotFound("User not found.")
+
+type UserQuery interface {
+	GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error)
+	ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error)
+	GetUserRole(ctx context.Context, userID string) (string, error)
+	CreateUser(ctx context.Context, tx pgx.Tx, req *api.CreateUserRequest) (*api.CreateUserResponse, error)
+	SetUserRole(ctx context.Context, tx pgx.Tx, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error)
+	AssignIssue(ctx context.Context, tx pgx.Tx, req *api.AssignIssueRequest, actor string) (*api.IssueAssigneesResponse, error)
+	UnassignIssue(ctx context.Context, tx pgx.Tx, req *api.UnassignIssueRequest, actor string) (*api.IssueAssigneesResponse, error)
+}
+
+const userColumns = `id, username, display_name, email, created_at, updated_at, role`
+
+type userQuery struct {
+	db *pgxpool.Pool
+}
+
+func NewUserQuery(db *pgxpool.Pool) UserQuery {
+	return &userQuery{
+		db: db,
+	}
+}
+
+func (q *userQuery) GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, errs.InvalidArgument("User ID cannot be empty.")
+	}
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(q.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("User with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	return &api.GetUserResponse{
+		User: user,
+	}, nil
+}
+
+func (q *userQuery) ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error) {
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY lower(username)`
+
+	rows, err := q.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*api.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &api.ListUsersResponse{
+		Users: users,
+	}, nil
+}
+
+// GetUserRole returns the role stored for a user, or ErrUserNotFound when the
+// user has no record yet.
+func (q *userQuery) GetUserRole(ctx context.Context, userID string) (string, error) {
+	var role string
+	query := `SELECT role FROM users WHERE id = $1`
+	if err := q.db.QueryRow(ctx, query, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+func (q *userQuery) CreateUser(ctx context.Context, tx pgx.Tx, req *api.CreateUserRequest) (*api.CreateUserResponse, error) {
+	if req == nil || req.User == nil {
+		return nil, errs.InvalidArgument("Please provide a valid user.")
+	}
+	query := `INSERT INTO users (id, username, display_name, email, created_at, updated_at, role) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + userColumns
+
+	createdAt := req.User.CreatedAt.AsTime()
+	updatedAt := req.User.UpdatedAt.AsTime()
+
+	user, err := scanUser(tx.QueryRow(ctx, query, req.User.Id, req.User.Username, req.User.DisplayName, req.User.Email, createdAt, updatedAt, req.User.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CreateUserResponse{
+		User: user,
+	}, nil
+}
+
+func (q *userQuery) SetUserRole(ctx context.Context, tx pgx.Tx, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error) {
+	if req == nil || req.Id == "" || req.Role == "" {
+		return nil, errs.InvalidArgument("User ID and role cannot be empty.")
+	}
+	query := `UPDATE users SET role = $2, updated_at = $3 WHERE id = $1 RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, req.Id, req.Role, time.Now()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFound(fmt.Sprintf("User with ID %s not found.", req.Id))
+		}
+		return nil, err
+	}
+
+	return &api.SetUserRoleResponse{
+		User: user,
+	}, nil
+}
+
+func (q *userQuery) AssignIssue(ctx context.Context, tx pgx.Tx, req *api.AssignIssueRequest, actor string) (*api.IssueAssigneesResponse, error) {
+	if req.IssueId == "" || req.UserId == "" {
+		return nil, errs.InvalidArgument("Issue ID and user ID cannot be empty.")
+	}
+	if err := q.checkAssigneeRefs(ctx, tx, req.ProjectId, req.IssueId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO issue_assignees (issue_id, user_id, assigned_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	tag, err := tx.Exec(ctx, query, req.IssueId, req.UserId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpIssueVersion(ctx, tx, req.IssueId); err != nil {
+			return nil, err
+		}
+		change := &api.FieldChange{Field: "assignee_id", After: &req.UserId}
+		if err := recordIssueChange(ctx, tx, IssueEventAssign, actor, req.ProjectId, req.IssueId, change); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.issueAssignees(ctx, tx, req.IssueId)
+}
+
+func (q *userQuery) UnassignIssue(ctx context.Context, tx pgx.Tx, req *api.UnassignIssueRequest, actor string) (*api.IssueAssigneesResponse, error) {
+	if req.IssueId == "" || req.UserId == "" {
+		return nil, errs.InvalidArgument("Issue ID and user ID cannot be empty.")
+	}
+	if err := q.checkAssigneeRefs(ctx, tx, req.ProjectId, req.IssueId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	query := `DELETE FROM issue_assignees WHERE issue_id = $1 AND user_id = $2`
+
+	tag, err := tx.Exec(ctx, query, req.IssueId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() > 0 {
+		if err := bumpIssueVersion(ctx, tx, req.IssueId); err != nil {
+			return nil, err
+		}
+		change := &api.FieldChange{Field: "assignee_id", Before: &req.UserId}
+		if err := recordIssueChange(ctx, tx, IssueEventUnassign, actor, req.ProjectId, req.IssueId, change); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.issueAssignees(ctx, tx, req.IssueId)
+}
+
+func (q *userQuery) checkAssigneeRefs(ctx context.Context, tx pgx.Tx, projectID string, issueID string, userID string) error {
+	query := `SELECT
+			EXISTS (SELECT 1 FROM issues WHERE id = $1 AND project_id = $3 AND deleted_at IS NULL),
+			EXISTS (SELECT 1 FROM users WHERE id = $2)`
+
+	var issueExists, userExists bool
+	if err := tx.QueryRow(ctx, query, issueID, userID, projectID).Scan(&issueExists, &userExists); err != nil {
+		return err
+	}
+	if !issueExists {
+		return errs.NotFound(fmt.Sprintf("Issue with ID %s not found.", issueID))
+	}
+	if !userExists {
+		return errs.NotFound(fmt.Sprintf("User with ID %s not found.", userID))
+	}
+	return nil
+}
+
+func (q *userQuery) issueAssignees(ctx context.Context, tx pgx.Tx, issueID string) (*api.IssueAssigneesResponse, error) {
+	assignees, err := assigneesByIssue(ctx, tx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.IssueAssigneesResponse{
+		IssueId:     issueID,
+		AssigneeIds: assignees[issueID],
+	}, nil
+}
+
+// assigneesByIssue loads the assignee IDs of every given issue in a single
+// query, keyed by issue ID.
+func assigneesByIssue(ctx context.Context, db querier, issueIDs []string) (map[string][]string, error) {
+	query := `SELECT issue_id, user_id FROM issue_assignees WHERE issue_id = ANY($1::uuid[]) ORDER BY assigned_at, user_id`
+
+	rows, err := db.Query(ctx, query, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issue assignees: %w", err)
+	}
+	defer rows.Close()
+
+	assignees := make(map[string][]string, len(issueIDs))
+	for rows.Next() {
+		var issueID, userID string
+		if err := rows.Scan(&issueID, &userID); err != nil {
+			return nil, err
+		}
+		assignees[issueID] = append(assignees[issueID], userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignees, nil
+}
+
+func scanUser(row pgx.Row) (*api.User, error) {
+	var user api.User
+	var displayName *string
+	var createdAt, updatedAt *time.Time
+
+	if err := row.Scan(&user.Id, &user.Username, &displayName, &user.Email, &createdAt, &updatedAt, &user.Role); err != nil {
+		return nil, err
+	}
+	if displayName != nil {
+		user.DisplayName = *displayName
+	}
+	if createdAt != nil {
+		user.CreatedAt = timestamppb.New(*createdAt)
+	}
+	if updatedAt != nil {
+		user.UpdatedAt = timestamppb.New(*updatedAt)
+	}
+
+	return &user, nil
+}
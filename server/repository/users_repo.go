@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserRepository interface {
+	GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error)
+	ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error)
+	GetUserRole(ctx context.Context, userID string) (string, error)
+	CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.CreateUserResponse, error)
+	SetUserRole(ctx context.Context, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error)
+	AssignIssue(ctx context.Context, req *api.AssignIssueRequest, actor string) (*api.IssueAssigneesResponse, error)
+	UnassignIssue(ctx context.Context, req *api.UnassignIssueRequest, actor string) (*api.IssueAssigneesResponse, error)
+}
+
+type userRepository struct {
+	db        Store
+	userQuery query.UserQuery
+}
+
+func NewUserRepository(db Store, userQuery query.UserQuery) UserRepository {
+	return &userRepository{
+		db:        db,
+		userQuery: userQuery,
+	}
+}
+
+func (r *userRepository) GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error) {
+	var user *api.GetUserResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		user, err = r.userQuery.GetUser(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *userRepository) ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error) {
+	var users *api.ListUsersResponse
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		users, err = r.userQuery.ListUsers(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) GetUserRole(ctx context.Context, userID string) (string, error) {
+	var role string
+
+	err := r.db.WithoutTx(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		role, err = r.userQuery.GetUserRole(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+	return role, nil
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.CreateUserResponse, error) {
+	var user *api.CreateUserResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		user, err = r.userQuery.CreateUser(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *userRepository) SetUserRole(ctx context.Context, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error) {
+	var user *api.SetUserRoleResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		user, err = r.userQuery.SetUserRole(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user role: %w", err)
+	}
+	return user, nil
+}
+
+func (r *userRepository) AssignIssue(ctx context.Context, req *api.AssignIssueRequest, actor string) (*api.IssueAssigneesResponse, error) {
+	var res *api.IssueAssigneesResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.userQuery.AssignIssue(ctx, tx, req, actor)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign issue: %w", err)
+	}
+	return res, nil
+}
+
+func (r *userRepository) UnassignIssue(ctx context.Context, req *api.UnassignIssueRequest, actor string) (*api.IssueAssigneesResponse, error) {
+	var res *api.IssueAssigneesResponse
+
+	err := r.db.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		res, err = r.userQuery.UnassignIssue(ctx, tx, req, actor)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign issue: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxCommentLength = 65536
+
+type CommentService interface {
+	GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error)
+	ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error)
+	ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error)
+	CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error)
+	UpdateComment(ctx context.Context, req *api.UpdateCommentRequest) (*api.UpdateCommentResponse, error)
+	DeleteComment(ctx context.Context, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error)
+}
+
+type commentService struct {
+	repo   repository.CommentRepository
+	policy Policy
+}
+
+func NewCommentService(repo repository.CommentRepository, policy Policy) CommentService {
+	return &commentService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (s *commentService) GetComment(ctx context.Context, req *api.GetCommentRequest) (*api.GetCommentResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadComments); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.GetComment(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get comment", zap.Error(err))
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *commentService) ListComments(ctx context.Context, req *api.ListCommentsRequest) (*api.ListCommentsResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadComments); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListComments(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list comments", zap.Error(err))
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *commentService) ListCommentRevisions(ctx context.Context, req *api.ListCommentRevisionsRequest) (*api.ListCommentRevisionsResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadComments); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.ListCommentRevisions(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list comment revisions", zap.Error(err))
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *commentService) CreateComment(ctx context.Context, req *api.CreateCommentRequest) (*api.CreateCommentResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionCreateComment); err != nil {
+		return nil, err
+	}
+
+	if err := validateCommentBody(req.Comment.Body); err != nil {
+		return nil, err
+	}
+
+	if userID, ok := auth.UserID(ctx); ok {
+		req.Comment.AuthorId = userID
+	}
+	if req.Comment.AuthorId == "" {
+		return nil, errs.Unauthenticated("Comments must have an author.")
+	}
+	authorID, err := resolveUserID(ctx, req.Comment.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.New(time.Now())
+	req.Comment.Id = uuid.New().String()
+	req.Comment.AuthorId = authorID
+	req.Comment.CreatedAt = now
+	req.Comment.UpdatedAt = now
+
+	res, err := s.repo.CreateComment(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to create comment", zap.Error(err))
+		if errors.Is(err, query.ErrReferenceNotFound) {
+			return nil, errs.InvalidArgument("Author does not exist.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *commentService) UpdateComment(ctx context.Context, req *api.UpdateCommentRequest) (*api.UpdateCommentResponse, error) {
+	if err := validateCommentBody(req.Comment.Body); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAuthor(ctx, ActionUpdateComment, req.ProjectId, req.Comment.IssueId, req.Comment.Id); err != nil {
+		return nil, err
+	}
+	req.Comment.UpdatedAt = timestamppb.New(time.Now())
+
+	editedBy, _ := auth.UserID(ctx)
+
+	res, err := s.repo.UpdateComment(ctx, req, editedBy)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update comment", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, req *api.DeleteCommentRequest) (*api.DeleteCommentResponse, error) {
+	if err := s.authorizeAuthor(ctx, ActionDeleteComment, req.ProjectId, req.IssueId, req.Id); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.DeleteComment(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete comment", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+// authorizeAuthor checks an action on a comment its author is allowed to
+// perform. Membership of the project is verified before the comment is
+// loaded.
+func (s *commentService) authorizeAuthor(ctx context.Context, action Action, projectID string, issueID string, commentID string) error {
+	if err := s.policy.AuthorizeProject(ctx, projectID, ActionReadComments); err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetComment(ctx, &api.GetCommentRequest{IssueId: issueID, Id: commentID, ProjectId: projectID})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get comment", zap.Error(err))
+		return err
+	}
+	return s.policy.AuthorizeProject(ctx, projectID, action, current.Comment.AuthorId)
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return errs.InvalidField("body", "Comment body cannot be empty.")
+	}
+	if len(body) > maxCommentLength {
+		return errs.InvalidField("body", fmt.Sprintf("Comment body must be at most %d bytes.", maxCommentLength))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/errs"
+)
+
+type ConfigService interface {
+	// ReloadConfig re-reads the configuration, applying the reloadable values
+	// that changed. An invalid configuration fails the precondition and is not
+	// applied.
+	ReloadConfig(ctx context.Context) (*config.ReloadResult, error)
+}
+
+type configService struct {
+	reloader *config.Reloader
+	policy   Policy
+}
+
+func NewConfigService(reloader *config.Reloader, policy Policy) ConfigService {
+	return &configService{
+		reloader: reloader,
+		policy:   policy,
+	}
+}
+
+func (s *configService) ReloadConfig(ctx context.Context) (*config.ReloadResult, error) {
+	if err := s.policy.Authorize(ctx, ActionReloadConfig); err != nil {
+		return nil, err
+	}
+
+	result, err := s.reloader.Reload(ctx)
+	if err != nil {
+		return nil, errs.FailedPrecondition(err.Error())
+	}
+	return &result, nil
+}
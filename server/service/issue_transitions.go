@@ -0,0 +1,44 @@
+package service
+
+import (
+	api "github.com/daffaromero/matesite/server/protobuf"
+)
+
+// issueTransitions lists, for every status, the statuses an issue may move
+// to next. Closed and resolved issues can only be reopened; everything else
+// may be closed directly.
+var issueTransitions = map[api.IssueStatus][]api.IssueStatus{
+	api.IssueStatus_ISSUE_STATUS_OPEN: {
+		api.IssueStatus_ISSUE_STATUS_IN_PROGRESS,
+		api.IssueStatus_ISSUE_STATUS_BLOCKED,
+		api.IssueStatus_ISSUE_STATUS_RESOLVED,
+		api.IssueStatus_ISSUE_STATUS_CLOSED,
+	},
+	api.IssueStatus_ISSUE_STATUS_IN_PROGRESS: {
+		api.IssueStatus_ISSUE_STATUS_OPEN,
+		api.IssueStatus_ISSUE_STATUS_BLOCKED,
+		api.IssueStatus_ISSUE_STATUS_RESOLVED,
+		api.IssueStatus_ISSUE_STATUS_CLOSED,
+	},
+	api.IssueStatus_ISSUE_STATUS_BLOCKED: {
+		api.IssueStatus_ISSUE_STATUS_OPEN,
+		api.IssueStatus_ISSUE_STATUS_IN_PROGRESS,
+		api.IssueStatus_ISSUE_STATUS_CLOSED,
+	},
+	api.IssueStatus_ISSUE_STATUS_RESOLVED: {
+		api.IssueStatus_ISSUE_STATUS_OPEN,
+		api.IssueStatus_ISSUE_STATUS_CLOSED,
+	},
+	api.IssueStatus_ISSUE_STATUS_CLOSED: {
+		api.IssueStatus_ISSUE_STATUS_OPEN,
+	},
+}
+
+func canTransition(from, to api.IssueStatus) bool {
+	for _, next := range issueTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
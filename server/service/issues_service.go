@@ -2,57 +2,107 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
 	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/metrics"
 	api "github.com/daffaromero/matesite/server/protobuf"
 	"github.com/daffaromero/matesite/server/repository"
-	"github.com/gofiber/fiber/v3"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/daffaromero/matesite/server/tracing"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var errVersionRequired = errs.PreconditionRequired("The version of the issue being changed must be provided.")
+
 type IssueService interface {
 	GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error)
 	ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error)
 	CreateIssue(ctx context.Context, req *api.CreateIssueRequest, title string, description string) (*api.CreateIssueResponse, error)
-	UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest, title string, description string) (*api.UpdateIssueResponse, error)
+	UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest) (*api.UpdateIssueResponse, error)
 	DeleteIssue(ctx context.Context, req *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error)
+	TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest) (*api.TransitionIssueResponse, error)
+	ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error)
+	ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error)
+	RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest) (*api.RestoreIssueResponse, error)
+	PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest) (*api.PurgeIssueResponse, error)
 }
 
 type issueService struct {
 	repo   repository.IssueRepository
-	logger *logger.Log
+	policy Policy
 }
 
-func NewIssueService(repo repository.IssueRepository, logger *logger.Log) IssueService {
+func NewIssueService(repo repository.IssueRepository, policy Policy) IssueService {
 	return &issueService{
 		repo:   repo,
-		logger: logger,
+		policy: policy,
 	}
 }
 
-func (s *issueService) GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error) {
+func (s *issueService) GetIssue(ctx context.Context, req *api.GetIssueRequest) (_ *api.GetIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.GetIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadIssues); err != nil {
+		return nil, err
+	}
+
 	issue, err := s.repo.GetIssue(ctx, req)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to get issue: %v", err))
+		logger.FromContext(ctx).Error("Failed to get issue", zap.Error(err))
 		return nil, err
 	}
 	return issue, nil
 }
 
-func (s *issueService) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error) {
+func (s *issueService) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (_ *api.ListIssuesResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.ListIssues")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadIssues); err != nil {
+		return nil, err
+	}
+	if req.Assignee != "" {
+		assignee, err := resolveUserID(ctx, req.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		req.Assignee = assignee
+	}
+	if req.Reporter != "" {
+		reporter, err := resolveUserID(ctx, req.Reporter)
+		if err != nil {
+			return nil, err
+		}
+		req.Reporter = reporter
+	}
+
 	issues, err := s.repo.ListIssues(ctx, req)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to list issues: %v", err))
+		logger.FromContext(ctx).Error("Failed to list issues", zap.Error(err))
 		return nil, err
 	}
 	return issues, nil
 }
 
-func (s *issueService) CreateIssue(ctx context.Context, req *api.CreateIssueRequest, title string, description string) (*api.CreateIssueResponse, error) {
+func (s *issueService) CreateIssue(ctx context.Context, req *api.CreateIssueRequest, title string, description string) (_ *api.CreateIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.CreateIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.Issue.ProjectId, ActionCreateIssue); err != nil {
+		return nil, err
+	}
+
 	now := &timestamppb.Timestamp{
 		Seconds: time.Now().Unix(),
 		Nanos:   int32(time.Now().Nanosecond()),
@@ -60,44 +110,252 @@ func (s *issueService) CreateIssue(ctx context.Context, req *api.CreateIssueRequ
 	req.Issue.Id = uuid.New().String()
 	req.Issue.Title = title
 	req.Issue.Description = description
+	req.Issue.Status = api.IssueStatus_ISSUE_STATUS_OPEN
+	if userID, ok := auth.UserID(ctx); ok {
+		req.Issue.ReporterId = userID
+	} else if req.Issue.ReporterId != "" {
+		reporter, err := resolveUserID(ctx, req.Issue.ReporterId)
+		if err != nil {
+			return nil, err
+		}
+		req.Issue.ReporterId = reporter
+	}
 	req.Issue.CreatedAt = now
 	req.Issue.UpdatedAt = now
 
-	res, err := s.repo.CreateIssue(ctx, req)
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.CreateIssue(ctx, req, actor)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to create issue: %v", err))
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return nil, fiber.NewError(fiber.StatusBadRequest, "Issue already exists.")
+		logger.FromContext(ctx).Error("Failed to create issue", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("Issue already exists.")
+		}
+		if errors.Is(err, query.ErrReferenceNotFound) {
+			return nil, errs.InvalidArgument("Reporter does not exist.")
 		}
 		return nil, err
 	}
+	metrics.IssuesCreated.Inc()
 
 	return res, nil
 }
 
-func (s *issueService) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest, title string, description string) (*api.UpdateIssueResponse, error) {
+func (s *issueService) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest) (_ *api.UpdateIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.UpdateIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if req.ExpectedVersion <= 0 {
+		return nil, errVersionRequired
+	}
+	paths, err := issueUpdateMask(req)
+	if err != nil {
+		return nil, err
+	}
+	req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+
+	current, err := s.currentIssue(ctx, req.Issue.ProjectId, req.Issue.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.AuthorizeProject(ctx, req.Issue.ProjectId, ActionUpdateIssue, current.Issue.ReporterId); err != nil {
+		return nil, err
+	}
+
 	now := &timestamppb.Timestamp{
 		Seconds: time.Now().Unix(),
 		Nanos:   int32(time.Now().Nanosecond()),
 	}
-	req.Issue.Title = title
-	req.Issue.Description = description
 	req.Issue.UpdatedAt = now
 
-	res, err := s.repo.UpdateIssue(ctx, req)
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.UpdateIssue(ctx, req, actor)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to update issue: %v", err))
+		logger.FromContext(ctx).Error("Failed to update issue", zap.Error(err))
 		return nil, err
 	}
 
 	return res, nil
 }
 
-func (s *issueService) DeleteIssue(ctx context.Context, req *api.DeleteIssueRequest) (*api.DeleteIssueResponse, error) {
-	res, err := s.repo.DeleteIssue(ctx, req)
+func (s *issueService) DeleteIssue(ctx context.Context, req *api.DeleteIssueRequest) (_ *api.DeleteIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.DeleteIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if req.ExpectedVersion <= 0 {
+		return nil, errVersionRequired
+	}
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionDeleteIssue); err != nil {
+		return nil, err
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.DeleteIssue(ctx, req, actor)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to delete issue: %v", err))
+		logger.FromContext(ctx).Error("Failed to delete issue", zap.Error(err))
 		return nil, err
 	}
+	metrics.IssuesDeleted.Inc()
+	return res, nil
+}
+
+func (s *issueService) TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest) (_ *api.TransitionIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.TransitionIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if _, ok := issueTransitions[req.Status]; !ok {
+		return nil, errs.InvalidField("status", "A valid target status must be provided.")
+	}
+	// Transitions are recorded as made by the caller, never by whoever the
+	// request names.
+	req.ChangedBy, _ = auth.UserID(ctx)
+
+	current, err := s.currentIssue(ctx, req.ProjectId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := append([]string{current.Issue.ReporterId}, current.Issue.AssigneeIds...)
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionTransition, owners...); err != nil {
+		return nil, err
+	}
+
+	from := current.Issue.Status
+	if !canTransition(from, req.Status) {
+		return nil, errs.FailedPrecondition(
+			fmt.Sprintf("Issue cannot move from %s to %s.", query.IssueStatusName(from), query.IssueStatusName(req.Status)))
+	}
+
+	res, err := s.repo.TransitionIssue(ctx, req, from)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to transition issue", zap.Error(err))
+		return nil, err
+	}
+	metrics.IssueTransitions.WithLabelValues(query.IssueStatusName(from), query.IssueStatusName(req.Status)).Inc()
+	if req.Status == api.IssueStatus_ISSUE_STATUS_CLOSED {
+		metrics.IssuesClosed.Inc()
+	}
+
 	return res, nil
 }
+
+func (s *issueService) ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (_ *api.ListIssueEventsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.ListIssueEvents")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadIssues); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListIssueEvents(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list issue events", zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *issueService) ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (_ *api.ListDeletedIssuesResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.ListDeletedIssues")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadTrash); err != nil {
+		return nil, err
+	}
+
+	issues, err := s.repo.ListDeletedIssues(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list deleted issues", zap.Error(err))
+		return nil, err
+	}
+	return issues, nil
+}
+
+func (s *issueService) RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest) (_ *api.RestoreIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.RestoreIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionRestoreIssue); err != nil {
+		return nil, err
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.RestoreIssue(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to restore issue", zap.Error(err))
+		return nil, err
+	}
+	metrics.IssuesRestored.Inc()
+	return res, nil
+}
+
+func (s *issueService) PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest) (_ *api.PurgeIssueResponse, err error) {
+	ctx, span := tracing.Start(ctx, "IssueService.PurgeIssue")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionPurgeIssue); err != nil {
+		return nil, err
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.PurgeIssue(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to purge issue", zap.Error(err))
+		return nil, err
+	}
+	metrics.IssuesPurged.WithLabelValues("manual").Inc()
+	return res, nil
+}
+
+// issueUpdateMask resolves the fields an update changes. Without a mask every
+// non-empty field is changed, which is how updates worked before masks.
+func issueUpdateMask(req *api.UpdateIssueRequest) ([]string, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if req.Issue.Title != "" {
+			paths = append(paths, "title")
+		}
+		if req.Issue.Description != "" {
+			paths = append(paths, "description")
+		}
+		if len(paths) == 0 {
+			return nil, errs.InvalidArgument("At least one field (title or description) must be provided for update.")
+		}
+	}
+
+	allowed := query.IssueUpdatePaths()
+	var mask []string
+	for _, path := range paths {
+		if !slices.Contains(allowed, path) {
+			return nil, errs.InvalidField("update_mask",
+				fmt.Sprintf("Field %s cannot be updated, the update mask may only name %s.", path, strings.Join(allowed, " and ")))
+		}
+		if !slices.Contains(mask, path) {
+			mask = append(mask, path)
+		}
+	}
+
+	if slices.Contains(mask, "title") && strings.TrimSpace(req.Issue.Title) == "" {
+		return nil, errs.InvalidField("title", "Title cannot be empty.")
+	}
+	return mask, nil
+}
+
+// currentIssue loads an issue for an ownership check. Membership of the
+// project is verified first, so outsiders cannot learn which issues exist.
+func (s *issueService) currentIssue(ctx context.Context, projectID string, id string) (*api.GetIssueResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, projectID, ActionReadIssues); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetIssue(ctx, &api.GetIssueRequest{Id: id, ProjectId: projectID})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get issue", zap.Error(err))
+		return nil, err
+	}
+	return current, nil
+}
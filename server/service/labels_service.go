@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultLabelColor  = "#cccccc"
+	maxLabelNameLength = 64
+)
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type LabelService interface {
+	GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error)
+	ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error)
+	CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error)
+	UpdateLabel(ctx context.Context, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error)
+	DeleteLabel(ctx context.Context, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error)
+	AddIssueLabel(ctx context.Context, req *api.AddIssueLabelRequest) (*api.IssueLabelsResponse, error)
+	RemoveIssueLabel(ctx context.Context, req *api.RemoveIssueLabelRequest) (*api.IssueLabelsResponse, error)
+}
+
+type labelService struct {
+	repo   repository.LabelRepository
+	policy Policy
+}
+
+func NewLabelService(repo repository.LabelRepository, policy Policy) LabelService {
+	return &labelService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (s *labelService) GetLabel(ctx context.Context, req *api.GetLabelRequest) (*api.GetLabelResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionReadLabels); err != nil {
+		return nil, err
+	}
+
+	label, err := s.repo.GetLabel(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get label", zap.Error(err))
+		return nil, err
+	}
+	return label, nil
+}
+
+func (s *labelService) ListLabels(ctx context.Context, req *api.ListLabelsRequest) (*api.ListLabelsResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionReadLabels); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.repo.ListLabels(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list labels", zap.Error(err))
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (s *labelService) CreateLabel(ctx context.Context, req *api.CreateLabelRequest) (*api.CreateLabelResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionManageLabels); err != nil {
+		return nil, err
+	}
+
+	req.Label.Name = strings.TrimSpace(req.Label.Name)
+	if req.Label.Name == "" {
+		return nil, errs.InvalidField("name", "Label name is required.")
+	}
+	if req.Label.Color == "" {
+		req.Label.Color = defaultLabelColor
+	}
+	if err := validateLabel(req.Label); err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.New(time.Now())
+	req.Label.Id = uuid.New().String()
+	req.Label.CreatedAt = now
+	req.Label.UpdatedAt = now
+
+	res, err := s.repo.CreateLabel(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to create label", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("Label already exists.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *labelService) UpdateLabel(ctx context.Context, req *api.UpdateLabelRequest) (*api.UpdateLabelResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionManageLabels); err != nil {
+		return nil, err
+	}
+
+	req.Label.Name = strings.TrimSpace(req.Label.Name)
+	if err := validateLabel(req.Label); err != nil {
+		return nil, err
+	}
+	req.Label.UpdatedAt = timestamppb.New(time.Now())
+
+	res, err := s.repo.UpdateLabel(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update label", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("Label already exists.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *labelService) DeleteLabel(ctx context.Context, req *api.DeleteLabelRequest) (*api.DeleteLabelResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionManageLabels); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.DeleteLabel(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete label", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *labelService) AddIssueLabel(ctx context.Context, req *api.AddIssueLabelRequest) (*api.IssueLabelsResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionTagIssue); err != nil {
+		return nil, err
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.AddIssueLabel(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to add issue label", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *labelService) RemoveIssueLabel(ctx context.Context, req *api.RemoveIssueLabelRequest) (*api.IssueLabelsResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionTagIssue); err != nil {
+		return nil, err
+	}
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.RemoveIssueLabel(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to remove issue label", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+// validateLabel checks the fields that are set; empty fields are left to
+// defaults on create and untouched on update.
+func validateLabel(label *api.Label) error {
+	if utf8.RuneCountInString(label.Name) > maxLabelNameLength {
+		return errs.InvalidField("name", fmt.Sprintf("Label name must be at most %d characters.", maxLabelNameLength))
+	}
+	if label.Color != "" && !labelColorPattern.MatchString(label.Color) {
+		return errs.InvalidField("color", "Label color must be a hex color such as #1d76db.")
+	}
+	return nil
+}
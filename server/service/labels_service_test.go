@@ -0,0 +1,34 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+)
+
+func TestValidateLabelNameLength(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		valid bool
+	}{
+		{"ASCII at the limit", strings.Repeat("a", maxLabelNameLength), true},
+		{"ASCII over the limit", strings.Repeat("a", maxLabelNameLength+1), false},
+		{"multibyte at the limit", strings.Repeat("é", maxLabelNameLength), true},
+		{"multibyte over the limit", strings.Repeat("界", maxLabelNameLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLabel(&api.Label{Name: tt.label})
+			if tt.valid && err != nil {
+				t.Fatalf("expected the name to be valid, got %v", err)
+			}
+			if !tt.valid && !errs.Is(err, errs.CodeInvalidArgument) {
+				t.Fatalf("expected %s, got %v", errs.CodeInvalidArgument, err)
+			}
+		})
+	}
+}
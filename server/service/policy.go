@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Role is a user's global access level. Roles are ordered, so every role is
+// granted everything the roles below it are.
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleViewer
+	RoleMember
+	RoleMaintainer
+)
+
+var roleNames = map[Role]string{
+	RoleViewer:     "viewer",
+	RoleMember:     "member",
+	RoleMaintainer: "maintainer",
+}
+
+func (r Role) String() string {
+	if name, ok := roleNames[r]; ok {
+		return name
+	}
+	return "none"
+}
+
+func ParseRole(name string) (Role, error) {
+	for role, roleName := range roleNames {
+		if roleName == name {
+			return role, nil
+		}
+	}
+	return RoleNone, fmt.Errorf("invalid role %q", name)
+}
+
+type Action string
+
+// Issue, comment and project management actions are scoped to a project and
+// checked with AuthorizeProject; the rest are global.
+const (
+	ActionReadIssues    Action = "issues:read"
+	ActionCreateIssue   Action = "issues:create"
+	ActionUpdateIssue   Action = "issues:update"
+	ActionDeleteIssue   Action = "issues:delete"
+	ActionReadTrash     Action = "issues:trash"
+	ActionRestoreIssue  Action = "issues:restore"
+	ActionPurgeIssue    Action = "issues:purge"
+	ActionTransition    Action = "issues:transition"
+	ActionReadLabels    Action = "labels:read"
+	ActionManageLabels  Action = "labels:manage"
+	ActionTagIssue      Action = "issues:tag"
+	ActionReadUsers     Action = "users:read"
+	ActionManageUsers   Action = "users:manage"
+	ActionAssignIssue   Action = "issues:assign"
+	ActionReadComments  Action = "comments:read"
+	ActionCreateComment Action = "comments:create"
+	ActionUpdateComment Action = "comments:update"
+	ActionDeleteComment Action = "comments:delete"
+	ActionReadProject   Action = "projects:read"
+	ActionCreateProject Action = "projects:create"
+	ActionManageProject Action = "projects:manage"
+	ActionReloadConfig  Action = "config:reload"
+)
+
+// rule grants an action to every user with at least Any, and to users with at
+// least Owner when they own the resource being acted on.
+type rule struct {
+	Any   Role
+	Owner Role
+}
+
+var policyRules = map[Action]rule{
+	ActionReadIssues:    {Any: RoleViewer},
+	ActionCreateIssue:   {Any: RoleMember},
+	ActionUpdateIssue:   {Any: RoleMaintainer, Owner: RoleMember},
+	ActionDeleteIssue:   {Any: RoleMaintainer},
+	ActionReadTrash:     {Any: RoleMaintainer},
+	ActionRestoreIssue:  {Any: RoleMaintainer},
+	ActionPurgeIssue:    {Any: RoleMaintainer},
+	ActionTransition:    {Any: RoleMaintainer, Owner: RoleMember},
+	ActionReadLabels:    {Any: RoleViewer},
+	ActionManageLabels:  {Any: RoleMaintainer},
+	ActionTagIssue:      {Any: RoleMember},
+	ActionReadUsers:     {Any: RoleViewer},
+	ActionManageUsers:   {Any: RoleMaintainer},
+	ActionAssignIssue:   {Any: RoleMember},
+	ActionReadComments:  {Any: RoleViewer},
+	ActionCreateComment: {Any: RoleMember},
+	ActionUpdateComment: {Any: RoleMaintainer, Owner: RoleMember},
+	ActionDeleteComment: {Any: RoleMaintainer, Owner: RoleMember},
+	ActionReadProject:   {Any: RoleViewer},
+	ActionCreateProject: {Any: RoleMember},
+	ActionManageProject: {Any: RoleMaintainer},
+	ActionReloadConfig:  {Any: RoleMaintainer},
+}
+
+// Policy decides whether the caller of a request may perform an action.
+type Policy interface {
+	// Role returns the stored role of the caller, or RoleNone when the caller
+	// has no user record.
+	Role(ctx context.Context) (Role, error)
+	// Authorize fails with 403 unless the caller's role grants the action. The
+	// owners are the IDs of the users owning the resource acted on, if any.
+	Authorize(ctx context.Context, action Action, owners ...string) error
+	// AuthorizeProject is Authorize for an action inside a project, judged by
+	// the caller's role in that project. Global maintainers act as maintainers
+	// of every project. Callers that are not members get a 404, so project
+	// IDs cannot be probed.
+	AuthorizeProject(ctx context.Context, projectID string, action Action, owners ...string) error
+}
+
+type policy struct {
+	users    repository.UserRepository
+	projects repository.ProjectRepository
+}
+
+func NewPolicy(users repository.UserRepository, projects repository.ProjectRepository) Policy {
+	return &policy{
+		users:    users,
+		projects: projects,
+	}
+}
+
+func (p *policy) Role(ctx context.Context) (Role, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return RoleNone, errs.Unauthenticated("Authentication is required.")
+	}
+
+	name, err := p.users.GetUserRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, query.ErrUserNotFound) {
+			return RoleNone, nil
+		}
+		logger.FromContext(ctx).Error("Failed to get user role", zap.Error(err))
+		return RoleNone, err
+	}
+
+	role, err := ParseRole(name)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user role", zap.Error(err))
+		return RoleNone, err
+	}
+	return role, nil
+}
+
+func (p *policy) Authorize(ctx context.Context, action Action, owners ...string) error {
+	role, err := p.Role(ctx)
+	if err != nil {
+		return err
+	}
+	return p.authorize(ctx, role, action, owners)
+}
+
+func (p *policy) AuthorizeProject(ctx context.Context, projectID string, action Action, owners ...string) error {
+	role, err := p.projectRole(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	return p.authorize(ctx, role, action, owners)
+}
+
+func (p *policy) projectRole(ctx context.Context, projectID string) (Role, error) {
+	role, err := p.Role(ctx)
+	if err != nil || role == RoleMaintainer {
+		return role, err
+	}
+
+	notFound := errs.NotFound(fmt.Sprintf("Project with ID %s not found.", projectID))
+	if _, err := uuid.Parse(projectID); err != nil {
+		return RoleNone, notFound
+	}
+
+	userID, _ := auth.UserID(ctx)
+	name, err := p.projects.GetProjectRole(ctx, projectID, userID)
+	if err != nil {
+		if errors.Is(err, query.ErrNotProjectMember) {
+			return RoleNone, notFound
+		}
+		logger.FromContext(ctx).Error("Failed to get project role", zap.Error(err))
+		return RoleNone, err
+	}
+
+	projectRole, err := ParseRole(name)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get project role", zap.Error(err))
+		return RoleNone, err
+	}
+	return projectRole, nil
+}
+
+func (p *policy) authorize(ctx context.Context, role Role, action Action, owners []string) error {
+	rule, ok := policyRules[action]
+	if !ok {
+		return fmt.Errorf("no policy rule for action %q", action)
+	}
+	if role >= rule.Any {
+		return nil
+	}
+	if rule.Owner != RoleNone && role >= rule.Owner {
+		userID, _ := auth.UserID(ctx)
+		if slices.Contains(owners, userID) {
+			return nil
+		}
+	}
+
+	return errs.PermissionDenied(fmt.Sprintf("Role %s is not allowed to perform %s.", role, action))
+}
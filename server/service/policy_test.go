@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+)
+
+const (
+	testProjectID = "7d1c1a52-3f0e-4d47-9d6b-0a4cf8a7c001"
+	testIssueID   = "7d1c1a52-3f0e-4d47-9d6b-0a4cf8a7c002"
+)
+
+// Callers of the tests, by their global and project roles.
+const (
+	globalMaintainer  = "a0000000-0000-4000-8000-000000000001"
+	projectMaintainer = "a0000000-0000-4000-8000-000000000002"
+	member            = "a0000000-0000-4000-8000-000000000003"
+	reporter          = "a0000000-0000-4000-8000-000000000004"
+	assignee          = "a0000000-0000-4000-8000-000000000005"
+	viewer            = "a0000000-0000-4000-8000-000000000006"
+	nonMember         = "a0000000-0000-4000-8000-000000000007"
+	unknownUser       = "a0000000-0000-4000-8000-000000000008"
+	anonymous         = ""
+)
+
+var callerNames = map[string]string{
+	globalMaintainer:  "global maintainer",
+	projectMaintainer: "project maintainer",
+	member:            "member",
+	reporter:          "reporter",
+	assignee:          "assignee",
+	viewer:            "viewer",
+	nonMember:         "non-member",
+	unknownUser:       "unknown user",
+	anonymous:         "anonymous",
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	roles map[string]string
+}
+
+func (r *fakeUserRepository) GetUserRole(ctx context.Context, userID string) (string, error) {
+	role, ok := r.roles[userID]
+	if !ok {
+		return "", query.ErrUserNotFound
+	}
+	return role, nil
+}
+
+type fakeProjectRepository struct {
+	repository.ProjectRepository
+	roles map[string]string
+}
+
+func (r *fakeProjectRepository) GetProjectRole(ctx context.Context, projectID string, userID string) (string, error) {
+	role, ok := r.roles[userID]
+	if !ok || projectID != testProjectID {
+		return "", query.ErrNotProjectMember
+	}
+	return role, nil
+}
+
+// fakeIssueRepository succeeds at everything, and holds one open issue
+// reported by reporter and assigned to assignee.
+type fakeIssueRepository struct {
+	repository.IssueRepository
+}
+
+func (r *fakeIssueRepository) GetIssue(ctx context.Context, req *api.GetIssueRequest) (*api.GetIssueResponse, error) {
+	return &api.GetIssueResponse{Issue: &api.Issue{
+		Id:          req.Id,
+		ProjectId:   req.ProjectId,
+		ReporterId:  reporter,
+		AssigneeIds: []string{assignee},
+		Status:      api.IssueStatus_ISSUE_STATUS_OPEN,
+	}}, nil
+}
+
+func (r *fakeIssueRepository) ListIssues(ctx context.Context, req *api.ListIssuesRequest) (*api.ListIssuesResponse, error) {
+	return &api.ListIssuesResponse{}, nil
+}
+
+func (r *fakeIssueRepository) CreateIssue(ctx context.Context, req *api.CreateIssueRequest, actor string) (*api.CreateIssueResponse, error) {
+	return &api.CreateIssueResponse{Issue: req.Issue}, nil
+}
+
+func (r *fakeIssueRepository) UpdateIssue(ctx context.Context, req *api.UpdateIssueRequest, actor string) (*api.UpdateIssueResponse, error) {
+	return &api.UpdateIssueResponse{Issue: req.Issue}, nil
+}
+
+func (r *fakeIssueRepository) DeleteIssue(ctx context.Context, req *api.DeleteIssueRequest, actor string) (*api.DeleteIssueResponse, error) {
+	return &api.DeleteIssueResponse{}, nil
+}
+
+func (r *fakeIssueRepository) TransitionIssue(ctx context.Context, req *api.TransitionIssueRequest, from api.IssueStatus) (*api.TransitionIssueResponse, error) {
+	return &api.TransitionIssueResponse{}, nil
+}
+
+func (r *fakeIssueRepository) ListIssueEvents(ctx context.Context, req *api.ListIssueEventsRequest) (*api.ListIssueEventsResponse, error) {
+	return &api.ListIssueEventsResponse{}, nil
+}
+
+func (r *fakeIssueRepository) ListDeletedIssues(ctx context.Context, req *api.ListDeletedIssuesRequest) (*api.ListDeletedIssuesResponse, error) {
+	return &api.ListDeletedIssuesResponse{}, nil
+}
+
+func (r *fakeIssueRepository) RestoreIssue(ctx context.Context, req *api.RestoreIssueRequest, actor string) (*api.RestoreIssueResponse, error) {
+	return &api.RestoreIssueResponse{}, nil
+}
+
+func (r *fakeIssueRepository) PurgeIssue(ctx context.Context, req *api.PurgeIssueRequest, actor string) (*api.PurgeIssueResponse, error) {
+	return &api.PurgeIssueResponse{}, nil
+}
+
+func newTestIssueService() IssueService {
+	users := &fakeUserRepository{roles: map[string]string{
+		globalMaintainer:  "maintainer",
+		projectMaintainer: "viewer",
+		member:            "viewer",
+		reporter:          "viewer",
+		assignee:          "viewer",
+		viewer:            "viewer",
+		nonMember:         "member",
+	}}
+	projects := &fakeProjectRepository{roles: map[string]string{
+		projectMaintainer: "maintainer",
+		member:            "member",
+		reporter:          "member",
+		assignee:          "member",
+		viewer:            "viewer",
+	}}
+	return NewIssueService(&fakeIssueRepository{}, NewPolicy(users, projects))
+}
+
+// TestIssueServiceAuthorization checks every caller against every method.
+// Callers in allowed succeed; other project members are denied, callers
+// outside the project are told it does not exist, and anonymous callers must
+// authenticate.
+func TestIssueServiceAuthorization(t *testing.T) {
+	everyMember := []string{globalMaintainer, projectMaintainer, member, reporter, assignee, viewer}
+	contributors := []string{globalMaintainer, projectMaintainer, member, reporter, assignee}
+	maintainers := []string{globalMaintainer, projectMaintainer}
+
+	tests := []struct {
+		method  string
+		call    func(ctx context.Context, s IssueService) error
+		allowed []string
+	}{
+		{
+			method: "GetIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.GetIssue(ctx, &api.GetIssueRequest{ProjectId: testProjectID, Id: testIssueID})
+				return err
+			},
+			allowed: everyMember,
+		},
+		{
+			method: "ListIssues",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.ListIssues(ctx, &api.ListIssuesRequest{ProjectId: testProjectID})
+				return err
+			},
+			allowed: everyMember,
+		},
+		{
+			method: "CreateIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.CreateIssue(ctx, &api.CreateIssueRequest{Issue: &api.Issue{ProjectId: testProjectID}}, "Title", "Description")
+				return err
+			},
+			allowed: contributors,
+		},
+		{
+			method: "UpdateIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.UpdateIssue(ctx, &api.UpdateIssueRequest{
+					Issue:           &api.Issue{ProjectId: testProjectID, Id: testIssueID, Title: "Title"},
+					ExpectedVersion: 1,
+				})
+				return err
+			},
+			allowed: append([]string{reporter}, maintainers...),
+		},
+		{
+			method: "DeleteIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.DeleteIssue(ctx, &api.DeleteIssueRequest{ProjectId: testProjectID, Id: testIssueID, ExpectedVersion: 1})
+				return err
+			},
+			allowed: maintainers,
+		},
+		{
+			method: "TransitionIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.TransitionIssue(ctx, &api.TransitionIssueRequest{
+					ProjectId: testProjectID,
+					Id:        testIssueID,
+					Status:    api.IssueStatus_ISSUE_STATUS_IN_PROGRESS,
+				})
+				return err
+			},
+			allowed: append([]string{reporter, assignee}, maintainers...),
+		},
+		{
+			method: "ListIssueEvents",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.ListIssueEvents(ctx, &api.ListIssueEventsRequest{ProjectId: testProjectID, IssueId: testIssueID})
+				return err
+			},
+			allowed: everyMember,
+		},
+		{
+			method: "ListDeletedIssues",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.ListDeletedIssues(ctx, &api.ListDeletedIssuesRequest{ProjectId: testProjectID})
+				return err
+			},
+			allowed: maintainers,
+		},
+		{
+			method: "RestoreIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.RestoreIssue(ctx, &api.RestoreIssueRequest{ProjectId: testProjectID, Id: testIssueID})
+				return err
+			},
+			allowed: maintainers,
+		},
+		{
+			method: "PurgeIssue",
+			call: func(ctx context.Context, s IssueService) error {
+				_, err := s.PurgeIssue(ctx, &api.PurgeIssueRequest{ProjectId: testProjectID, Id: testIssueID})
+				return err
+			},
+			allowed: maintainers,
+		},
+	}
+
+	callers := []string{globalMaintainer, projectMaintainer, member, reporter, assignee, viewer, nonMember, unknownUser, anonymous}
+	for _, tt := range tests {
+		for _, caller := range callers {
+			t.Run(tt.method+"/"+callerNames[caller], func(t *testing.T) {
+				ctx := context.Background()
+				if caller != anonymous {
+					ctx = auth.WithPrincipal(ctx, &auth.Principal{UserID: caller})
+				}
+
+				err := tt.call(ctx, newTestIssueService())
+
+				switch {
+				case slices.Contains(tt.allowed, caller):
+					if err != nil {
+						t.Fatalf("expected the call to be allowed, got %v", err)
+					}
+				case caller == anonymous:
+					if !errs.Is(err, errs.CodeUnauthenticated) {
+						t.Fatalf("expected %s, got %v", errs.CodeUnauthenticated, err)
+					}
+				case caller == nonMember || caller == unknownUser:
+					if !errs.Is(err, errs.CodeNotFound) {
+						t.Fatalf("expected %s, got %v", errs.CodeNotFound, err)
+					}
+				default:
+					if !errs.Is(err, errs.CodePermissionDenied) {
+						t.Fatalf("expected %s, got %v", errs.CodePermissionDenied, err)
+					}
+				}
+			})
+		}
+	}
+}
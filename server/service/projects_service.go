@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxProjectNameLength = 128
+
+type ProjectService interface {
+	GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error)
+	ListProjects(ctx context.Context, req *api.ListProjectsRequest) (*api.ListProjectsResponse, error)
+	CreateProject(ctx context.Context, req *api.CreateProjectRequest) (*api.CreateProjectResponse, error)
+	UpdateProject(ctx context.Context, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error)
+	DeleteProject(ctx context.Context, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error)
+	ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error)
+	SetProjectMember(ctx context.Context, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error)
+	RemoveProjectMember(ctx context.Context, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error)
+}
+
+type projectService struct {
+	repo   repository.ProjectRepository
+	policy Policy
+}
+
+func NewProjectService(repo repository.ProjectRepository, policy Policy) ProjectService {
+	return &projectService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (s *projectService) GetProject(ctx context.Context, req *api.GetProjectRequest) (*api.GetProjectResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.Id, ActionReadProject); err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.GetProject(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get project", zap.Error(err))
+		return nil, err
+	}
+	return project, nil
+}
+
+// ListProjects lists the projects the caller is a member of, or every project
+// for global maintainers.
+func (s *projectService) ListProjects(ctx context.Context, req *api.ListProjectsRequest) (*api.ListProjectsResponse, error) {
+	role, err := s.policy.Role(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var memberID string
+	if role < RoleMaintainer {
+		memberID, _ = auth.UserID(ctx)
+	}
+
+	projects, err := s.repo.ListProjects(ctx, req, memberID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list projects", zap.Error(err))
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (s *projectService) CreateProject(ctx context.Context, req *api.CreateProjectRequest) (*api.CreateProjectResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionCreateProject); err != nil {
+		return nil, err
+	}
+
+	req.Project.Name = strings.TrimSpace(req.Project.Name)
+	if req.Project.Name == "" || utf8.RuneCountInString(req.Project.Name) > maxProjectNameLength {
+		return nil, errs.InvalidField("name", fmt.Sprintf("Project name is required and must be at most %d characters.", maxProjectNameLength))
+	}
+
+	now := timestamppb.New(time.Now())
+	req.Project.Id = uuid.New().String()
+	req.Project.CreatedAt = now
+	req.Project.UpdatedAt = now
+
+	ownerID, _ := auth.UserID(ctx)
+
+	res, err := s.repo.CreateProject(ctx, req, ownerID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to create project", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("Project already exists.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *projectService) UpdateProject(ctx context.Context, req *api.UpdateProjectRequest) (*api.UpdateProjectResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.Project.Id, ActionManageProject); err != nil {
+		return nil, err
+	}
+
+	req.Project.Name = strings.TrimSpace(req.Project.Name)
+	if utf8.RuneCountInString(req.Project.Name) > maxProjectNameLength {
+		return nil, errs.InvalidField("name", fmt.Sprintf("Project name must be at most %d characters.", maxProjectNameLength))
+	}
+	req.Project.UpdatedAt = timestamppb.New(time.Now())
+
+	res, err := s.repo.UpdateProject(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update project", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("Project already exists.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *projectService) DeleteProject(ctx context.Context, req *api.DeleteProjectRequest) (*api.DeleteProjectResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.Id, ActionManageProject); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.DeleteProject(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete project", zap.Error(err))
+		if errors.Is(err, query.ErrStillReferenced) {
+			return nil, errs.Conflict("Project still has issues.")
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *projectService) ListProjectMembers(ctx context.Context, req *api.ListProjectMembersRequest) (*api.ListProjectMembersResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionReadProject); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListProjectMembers(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list project members", zap.Error(err))
+		return nil, err
+	}
+	return members, nil
+}
+
+func (s *projectService) SetProjectMember(ctx context.Context, req *api.SetProjectMemberRequest) (*api.SetProjectMemberResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionManageProject); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userID
+	if _, err := ParseRole(req.Role); err != nil {
+		return nil, errs.InvalidField("role", "Role must be one of viewer, member or maintainer.")
+	}
+
+	res, err := s.repo.SetProjectMember(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to set project member", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *projectService) RemoveProjectMember(ctx context.Context, req *api.RemoveProjectMemberRequest) (*api.RemoveProjectMemberResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionManageProject); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userID
+
+	res, err := s.repo.RemoveProjectMember(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to remove project member", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/daffaromero/matesite/server/config"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/metrics"
+	"github.com/daffaromero/matesite/server/repository"
+	"go.uber.org/zap"
+)
+
+// TrashPurger is the retention job that permanently deletes issues which have
+// been in the trash for longer than the configured retention.
+type TrashPurger interface {
+	// Run purges expired issues every interval until ctx is done. It logs
+	// through the logger carried by ctx.
+	Run(ctx context.Context)
+	// Reconfigure replaces the configuration of a running job. A new interval
+	// takes effect at once, without purging early.
+	Reconfigure(config config.TrashConfig)
+}
+
+type trashPurger struct {
+	repo         repository.IssueRepository
+	config       atomic.Pointer[config.TrashConfig]
+	reconfigured chan struct{}
+}
+
+func NewTrashPurger(repo repository.IssueRepository, config config.TrashConfig) TrashPurger {
+	p := &trashPurger{
+		repo:         repo,
+		reconfigured: make(chan struct{}, 1),
+	}
+	p.config.Store(&config)
+	return p
+}
+
+func (p *trashPurger) Run(ctx context.Context) {
+	interval := p.config.Load().PurgeInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if p.config.Load().Retention <= 0 {
+		logger.FromContext(ctx).Info("Trash retention is disabled, deleted issues are kept until purged")
+	}
+	p.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.reconfigured:
+			if next := p.config.Load().PurgeInterval; next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+func (p *trashPurger) Reconfigure(config config.TrashConfig) {
+	p.config.Store(&config)
+	select {
+	case p.reconfigured <- struct{}{}:
+	default:
+	}
+}
+
+// purge deletes expired issues in batches, so that a large backlog does not
+// hold one long transaction open. Nothing is purged while retention is
+// disabled.
+func (p *trashPurger) purge(ctx context.Context) {
+	config := p.config.Load()
+	if config.Retention <= 0 {
+		return
+	}
+	deletedBefore := time.Now().Add(-config.Retention)
+
+	var total int
+	for ctx.Err() == nil {
+		purged, err := p.repo.PurgeExpiredIssues(ctx, deletedBefore, config.PurgeBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to purge expired issues", zap.Error(err))
+			return
+		}
+		total += purged
+		metrics.IssuesPurged.WithLabelValues("retention").Add(float64(purged))
+		if purged < config.PurgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.FromContext(ctx).Info("Purged expired issues", zap.Int("purged", total), zap.Time("deleted_before", deletedBefore))
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/daffaromero/matesite/server/auth"
+	"github.com/daffaromero/matesite/server/errs"
+	"github.com/daffaromero/matesite/server/helper/logger"
+	api "github.com/daffaromero/matesite/server/protobuf"
+	"github.com/daffaromero/matesite/server/repository"
+	"github.com/daffaromero/matesite/server/repository/query"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxUsernameLength = 64
+
+type UserService interface {
+	GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error)
+	ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error)
+	CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.CreateUserResponse, error)
+	SetUserRole(ctx context.Context, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error)
+	AssignIssue(ctx context.Context, req *api.AssignIssueRequest) (*api.IssueAssigneesResponse, error)
+	UnassignIssue(ctx context.Context, req *api.UnassignIssueRequest) (*api.IssueAssigneesResponse, error)
+}
+
+type userService struct {
+	repo   repository.UserRepository
+	policy Policy
+}
+
+func NewUserService(repo repository.UserRepository, policy Policy) UserService {
+	return &userService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+func (s *userService) GetUser(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionReadUsers); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	req.Id = userID
+
+	user, err := s.repo.GetUser(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userService) ListUsers(ctx context.Context, req *api.ListUsersRequest) (*api.ListUsersResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionReadUsers); err != nil {
+		return nil, err
+	}
+
+	users, err := s.repo.ListUsers(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list users", zap.Error(err))
+		return nil, err
+	}
+	return users, nil
+}
+
+// CreateUser lets maintainers create any user. Callers without a user record
+// may only register themselves, under the ID they authenticated as and with
+// the default viewer role.
+func (s *userService) CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.CreateUserResponse, error) {
+	role, err := s.policy.Role(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch role {
+	case RoleMaintainer:
+		req.User.Id = uuid.New().String()
+		if req.User.Role == "" {
+			req.User.Role = RoleViewer.String()
+		}
+		if _, err := ParseRole(req.User.Role); err != nil {
+			return nil, errs.InvalidField("role", "Role must be one of viewer, member or maintainer.")
+		}
+	case RoleNone:
+		userID, _ := auth.UserID(ctx)
+		if _, err := uuid.Parse(userID); err != nil {
+			return nil, errs.InvalidArgument("The authenticated subject is not a valid user ID.")
+		}
+		req.User.Id = userID
+		req.User.Role = RoleViewer.String()
+	default:
+		return nil, errs.PermissionDenied(fmt.Sprintf("Role %s is not allowed to perform %s.", role, ActionManageUsers))
+	}
+
+	req.User.Username = strings.TrimSpace(req.User.Username)
+	req.User.Email = strings.TrimSpace(req.User.Email)
+	if req.User.Username == "" || len(req.User.Username) > maxUsernameLength {
+		return nil, errs.InvalidField("username", fmt.Sprintf("Username is required and must be at most %d characters.", maxUsernameLength))
+	}
+	if strings.EqualFold(req.User.Username, auth.Me) {
+		return nil, errs.InvalidField("username", fmt.Sprintf("Username %q is reserved.", auth.Me))
+	}
+	if _, err := mail.ParseAddress(req.User.Email); err != nil {
+		return nil, errs.InvalidField("email", "A valid email address is required.")
+	}
+
+	now := timestamppb.New(time.Now())
+	req.User.CreatedAt = now
+	req.User.UpdatedAt = now
+
+	res, err := s.repo.CreateUser(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to create user", zap.Error(err))
+		if errors.Is(err, query.ErrAlreadyExists) {
+			return nil, errs.Conflict("User already exists.")
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *userService) SetUserRole(ctx context.Context, req *api.SetUserRoleRequest) (*api.SetUserRoleResponse, error) {
+	if err := s.policy.Authorize(ctx, ActionManageUsers); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	req.Id = userID
+	if _, err := ParseRole(req.Role); err != nil {
+		return nil, errs.InvalidField("role", "Role must be one of viewer, member or maintainer.")
+	}
+
+	res, err := s.repo.SetUserRole(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to set user role", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *userService) AssignIssue(ctx context.Context, req *api.AssignIssueRequest) (*api.IssueAssigneesResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionAssignIssue); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userID
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.AssignIssue(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to assign issue", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *userService) UnassignIssue(ctx context.Context, req *api.UnassignIssueRequest) (*api.IssueAssigneesResponse, error) {
+	if err := s.policy.AuthorizeProject(ctx, req.ProjectId, ActionAssignIssue); err != nil {
+		return nil, err
+	}
+
+	userID, err := resolveUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userID
+
+	actor, _ := auth.UserID(ctx)
+
+	res, err := s.repo.UnassignIssue(ctx, req, actor)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to unassign issue", zap.Error(err))
+		return nil, err
+	}
+	return res, nil
+}
+
+// resolveUserID replaces the "me" alias with the ID of the requesting user
+// and checks that any other value is a well-formed user ID.
+func resolveUserID(ctx context.Context, userID string) (string, error) {
+	if strings.EqualFold(userID, auth.Me) {
+		current, ok := auth.UserID(ctx)
+		if !ok {
+			return "", errs.Unauthenticated(fmt.Sprintf("%q can only be used by an identified user.", auth.Me))
+		}
+		return current, nil
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", errs.InvalidArgument(fmt.Sprintf("Invalid user ID %q.", userID))
+	}
+	return userID, nil
+}
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// TraceIDHeader is the response header, and gRPC header metadata key, carrying
+// the ID of the trace a request was served in.
+const TraceIDHeader = "X-Trace-Id"
+
+// NewMiddleware starts a server span for every HTTP request, continuing the
+// trace of the caller if the request carries a traceparent header, and stores
+// it in the request's user context. It must be registered before the logger
+// middleware, which logs the trace ID and handles errors, so that the status
+// recorded is the one written.
+func NewMiddleware() fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		parent := otel.GetTextMapPropagator().Extract(ctx.UserContext(), headerCarrier{ctx})
+		spanCtx, span := Start(parent, ctx.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Method()),
+				semconv.URLPath(ctx.Path()),
+			),
+		)
+		defer span.End()
+
+		if traceID := span.SpanContext().TraceID(); traceID.IsValid() {
+			ctx.Set(TraceIDHeader, traceID.String())
+		}
+		ctx.SetUserContext(spanCtx)
+
+		err := ctx.Next()
+
+		route := ctx.Route().Path
+		statusCode := ctx.Response().StatusCode()
+		span.SetName(fmt.Sprintf("%s %s", ctx.Method(), route))
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(statusCode))
+		if err != nil {
+			span.RecordError(err)
+		}
+		if statusCode >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		return err
+	}
+}
+
+// ServerHandler is the stats handler starting a server span for every gRPC
+// request.
+func ServerHandler() stats.Handler {
+	return otelgrpc.NewServerHandler()
+}
+
+// UnaryServerInterceptor returns the trace ID of every gRPC request in a
+// header, as NewMiddleware does for HTTP.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if traceID := trace.SpanContextFromContext(ctx).TraceID(); traceID.IsValid() {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(TraceIDHeader, traceID.String()))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// headerCarrier adapts the request and response headers of a Fiber request to
+// a propagation.TextMapCarrier.
+type headerCarrier struct {
+	ctx fiber.Ctx
+}
+
+func (c headerCarrier) Get(key string) string {
+	return c.ctx.Get(key)
+}
+
+func (c headerCarrier) Set(key string, value string) {
+	c.ctx.Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.ctx.Request().Header.VisitAll(func(key []byte, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
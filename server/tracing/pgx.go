@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer is a pgx.QueryTracer starting a client span for every SQL
+// statement, as a child of the span in the statement's context.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := sqlOperation(data.SQL)
+	ctx, _ = Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBNamespace(conn.Config().Database),
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return ctx
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if errors.Is(data.Err, pgx.ErrNoRows) {
+		span.End()
+		return
+	}
+	End(span, data.Err)
+}
+
+// sqlOperation is the first keyword of a statement, such as SELECT.
+func sqlOperation(sql string) string {
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return ""
+}
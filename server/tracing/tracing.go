@@ -0,0 +1,114 @@
+// Package tracing sets up OpenTelemetry tracing and holds the middleware and
+// hooks starting spans for HTTP and gRPC requests and SQL statements.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/daffaromero/matesite/server"
+
+// Exporters spans can be sent to.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Config describes where spans are exported.
+type Config struct {
+	// Exporter is ExporterNone, the default, ExporterOTLP or ExporterStdout.
+	// The OTLP exporter is configured through the standard
+	// OTEL_EXPORTER_OTLP_* environment variables.
+	Exporter string
+	// File is where the stdout exporter writes, instead of stdout.
+	File string
+	// SampleRatio is the fraction of new traces sampled. Traces started
+	// upstream follow the sampling decision of their parent.
+	SampleRatio float64
+	Service     string
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes and stops the exporter. With no
+// exporter, spans are not recorded, but incoming trace context is still
+// propagated, so logs keep the caller's trace ID.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	switch config.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		var err error
+		if exporter, err = otlptracegrpc.New(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+	case ExporterStdout:
+		var out io.Writer = os.Stdout
+		if config.File != "" {
+			file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			out, closer = file, file
+		}
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(out)); err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid trace exporter %q, expected %s, %s or %s", config.Exporter, ExporterNone, ExporterOTLP, ExporterStdout)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(config.Service)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Start starts a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End ends span, recording err if it is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -1,13 +0,0 @@
-package utils
-
-import (
-	"go.uber.org/zap"
-)
-
-func NewLogger() *zap.SugaredLogger {
-
-	logger, _ := zap.NewProduction()
-	sugar := logger.Sugar()
-
-	return sugar
-}
\ No newline at end of file
@@ -0,0 +1,338 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+	vault "github.com/hashicorp/vault/api"
+	"go.uber.org/zap"
+)
+
+const (
+	// connectTimeout bounds connecting and logging in when Shared is first
+	// called.
+	connectTimeout = 10 * time.Second
+	// retryDelay is how long Run waits before trying a failed login again.
+	retryDelay = 10 * time.Second
+)
+
+// Client reads the configuration secret from Vault, and keeps its token alive
+// while Run is running.
+type Client struct {
+	api    *vault.Client
+	config Config
+
+	// tokenMu guards the lease of the token in use.
+	tokenMu   sync.Mutex
+	ttl       time.Duration
+	renewable bool
+
+	// cacheMu guards the snapshot of the secret.
+	cacheMu   sync.Mutex
+	snapshot  map[string]string
+	fetchedAt time.Time
+}
+
+var (
+	shared     *Client
+	sharedErr  error
+	sharedOnce sync.Once
+)
+
+// Shared returns the client configured by the environment, connecting and
+// logging in on first use. It returns nil when Vault is not configured.
+func Shared() (*Client, error) {
+	sharedOnce.Do(func() {
+		config, err := ConfigFromEnv()
+		if err != nil {
+			sharedErr = fmt.Errorf("invalid Vault configuration: %w", err)
+			return
+		}
+		if !config.Enabled() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		defer cancel()
+		shared, sharedErr = New(ctx, config)
+	})
+	return shared, sharedErr
+}
+
+// New connects to Vault and logs in. Every error names the setting or the step
+// that failed, as it is reported at startup.
+func New(ctx context.Context, config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Vault configuration:\n%w", err)
+	}
+
+	apiConfig := vault.DefaultConfig()
+	if apiConfig.Error != nil {
+		return nil, fmt.Errorf("invalid Vault configuration: %w", apiConfig.Error)
+	}
+	apiConfig.Address = config.Addr
+	err := apiConfig.ConfigureTLS(&vault.TLSConfig{
+		CACert:        config.CACert,
+		ClientCert:    config.ClientCert,
+		ClientKey:     config.ClientKey,
+		TLSServerName: config.TLSServerName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS for Vault: %w", err)
+	}
+
+	api, err := vault.NewClient(apiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault client: %w", err)
+	}
+	c := &Client{
+		api:    api,
+		config: config,
+	}
+
+	if err := c.Ping(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.login(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Ping checks that Vault is reachable, initialized and unsealed. Standby nodes
+// count as healthy, as they forward requests to the active node.
+func (c *Client) Ping(ctx context.Context) error {
+	health, err := c.api.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("vault at %s is not reachable: %w", c.config.Addr, err)
+	}
+	if !health.Initialized {
+		return fmt.Errorf("vault at %s is not initialized", c.config.Addr)
+	}
+	if health.Sealed {
+		return fmt.Errorf("vault at %s is sealed", c.config.Addr)
+	}
+	return nil
+}
+
+// Secrets returns the string values of the configuration secret. A read is
+// served from cache for CacheTTL, or until Invalidate is called, after which
+// the secret is read again.
+func (c *Client) Secrets(ctx context.Context) (map[string]string, error) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if c.snapshot != nil && time.Since(c.fetchedAt) < c.config.CacheTTL {
+		return maps.Clone(c.snapshot), nil
+	}
+
+	secret, err := c.api.KVv2(c.config.Engine).Get(ctx, c.config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Vault secret %s at %s: %w", c.config.Path, c.config.Engine, err)
+	}
+
+	values := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		if value, ok := value.(string); ok {
+			values[key] = value
+		}
+	}
+	c.snapshot, c.fetchedAt = values, time.Now()
+	return maps.Clone(values), nil
+}
+
+// Invalidate drops the cached secret, so that the next call to Secrets reads
+// it from Vault again.
+func (c *Client) Invalidate() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.snapshot, c.fetchedAt = nil, time.Time{}
+}
+
+// Run keeps the token alive until ctx is done. A renewable token is renewed
+// once two thirds of its TTL have passed. When it can no longer be renewed,
+// the client logs in again, which a static token cannot do. It logs through
+// the logger carried by ctx.
+func (c *Client) Run(ctx context.Context) {
+	for {
+		ttl, renewable := c.lease()
+		if ttl <= 0 {
+			// The token does not expire.
+			return
+		}
+		if !sleep(ctx, ttl*2/3) {
+			return
+		}
+
+		if renewable {
+			err := c.renew(ctx)
+			if err == nil {
+				continue
+			}
+			logger.FromContext(ctx).Warn("Failed to renew Vault token", zap.Error(err))
+		}
+		if c.config.Auth == AuthToken {
+			logger.FromContext(ctx).Error("Vault token cannot be renewed, restart with a new VAULT_TOKEN before it expires", zap.Duration("expires_in", ttl/3))
+			return
+		}
+
+		for {
+			err := c.login(ctx)
+			if err == nil {
+				logger.FromContext(ctx).Info("Logged in to Vault again", zap.String("auth", c.config.Auth))
+				break
+			}
+			logger.FromContext(ctx).Error("Failed to log in to Vault", zap.Error(err))
+			if !sleep(ctx, retryDelay) {
+				return
+			}
+		}
+	}
+}
+
+// login authenticates with the configured method and switches the client to
+// the token it got.
+func (c *Client) login(ctx context.Context) error {
+	var secret *vault.Secret
+	var err error
+
+	switch c.config.Auth {
+	case AuthToken:
+		c.api.SetToken(c.config.Token)
+		secret, err = c.api.Auth().Token().LookupSelfWithContext(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to look up VAULT_TOKEN: %w", err)
+		}
+	case AuthAppRole:
+		secretID := c.config.SecretID
+		if c.config.SecretIDFile != "" {
+			if secretID, err = readCredential(c.config.SecretIDFile); err != nil {
+				return fmt.Errorf("failed to read VAULT_SECRET_ID_FILE: %w", err)
+			}
+		}
+		secret, err = c.loginWith(ctx, map[string]any{"role_id": c.config.RoleID, "secret_id": secretID})
+	case AuthKubernetes:
+		jwt, readErr := readCredential(c.config.JWTFile)
+		if readErr != nil {
+			return fmt.Errorf("failed to read the service account token for Vault: %w", readErr)
+		}
+		secret, err = c.loginWith(ctx, map[string]any{"role": c.config.Role, "jwt": jwt})
+	}
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return fmt.Errorf("failed to log in to Vault with %s: no token returned", c.config.Auth)
+	}
+
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return fmt.Errorf("failed to read the TTL of the Vault token: %w", err)
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return fmt.Errorf("failed to read whether the Vault token is renewable: %w", err)
+	}
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.ttl, c.renewable = ttl, renewable
+	return nil
+}
+
+// loginWith writes the credentials to the login path of the auth method, and
+// switches the client to the token it returns.
+func (c *Client) loginWith(ctx context.Context, credentials map[string]any) (*vault.Secret, error) {
+	path := "auth/" + c.config.AuthMount + "/login"
+
+	// The login is sent without the expiring token.
+	anonymous, err := c.api.Clone()
+	if err != nil {
+		return nil, err
+	}
+	anonymous.ClearToken()
+
+	secret, err := anonymous.Logical().WriteWithContext(ctx, path, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in to Vault with %s at %s: %w", c.config.Auth, path, err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("failed to log in to Vault with %s at %s: no token returned", c.config.Auth, path)
+	}
+
+	// The token it replaces is no longer used, and stops being redacted.
+	previous := c.api.Token()
+	logger.RegisterSecret(secret.Auth.ClientToken)
+	c.api.SetToken(secret.Auth.ClientToken)
+	logger.UnregisterSecret(previous)
+	return secret, nil
+}
+
+// renew extends the lease of the token. A token that comes back with less than
+// half its previous TTL has reached its max TTL, and is not renewed again.
+func (c *Client) renew(ctx context.Context) error {
+	secret, err := c.api.Auth().Token().RenewSelfWithContext(ctx, 0)
+	if err != nil {
+		return err
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return err
+	}
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if ttl < c.ttl/2 {
+		c.renewable = false
+	}
+	c.ttl = ttl
+	return nil
+}
+
+// as returns a copy of the API client that uses token instead of the client's
+// own.
+func (c *Client) as(token string) (*vault.Client, error) {
+	api, err := c.api.Clone()
+	if err != nil {
+		return nil, err
+	}
+	api.SetToken(token)
+	return api, nil
+}
+
+func (c *Client) lease() (time.Duration, bool) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.ttl, c.renewable
+}
+
+// readCredential reads a credential file, registering its contents with the
+// logger so that they are redacted.
+func readCredential(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	credential := strings.TrimSpace(string(raw))
+	logger.RegisterSecret(credential)
+	return credential, nil
+}
+
+// sleep waits for d, and reports false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
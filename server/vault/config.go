@@ -0,0 +1,149 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+)
+
+// Methods a client can authenticate with, selected by VAULT_AUTH.
+const (
+	AuthToken      = "token"
+	AuthAppRole    = "approle"
+	AuthKubernetes = "kubernetes"
+)
+
+// DefaultJWTFile is where Kubernetes mounts the service account token of a
+// pod.
+const DefaultJWTFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// Config describes how to reach Vault, log in and read the configuration
+// secret. It is read from the environment by ConfigFromEnv, mostly under the
+// names the Vault CLI uses.
+type Config struct {
+	// Addr is the URL of the server, such as https://vault:8200.
+	Addr string
+	// CACert is a PEM file of CA certificates to verify the server with,
+	// instead of the system roots. ClientCert and ClientKey are a PEM
+	// certificate and key for servers that require TLS client auth.
+	CACert        string
+	ClientCert    string
+	ClientKey     string
+	TLSServerName string
+
+	// Auth is AuthToken, AuthAppRole or AuthKubernetes. AuthMount is where
+	// the method is mounted, the method name by default.
+	Auth      string
+	AuthMount string
+	// Token is the static token of AuthToken.
+	Token string
+	// RoleID and SecretID, or the contents of SecretIDFile, are the AppRole
+	// credentials.
+	RoleID       string
+	SecretID     string
+	SecretIDFile string
+	// Role is the Kubernetes auth role, logged in to with the service
+	// account token in JWTFile.
+	Role    string
+	JWTFile string
+
+	// Engine is the mount of the KV v2 engine and Path the secret holding
+	// the configuration values, keyed by environment variable.
+	Engine string
+	Path   string
+	// CacheTTL is how long a read of the secret is served before it is read
+	// again.
+	CacheTTL time.Duration
+}
+
+// ConfigFromEnv reads the VAULT_* environment. VAULT_HOST and VAULT_PORT are
+// accepted in place of VAULT_ADDR, for a server on plain HTTP. Secrets are
+// registered with the logger so that they are redacted.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		Addr:          os.Getenv("VAULT_ADDR"),
+		CACert:        os.Getenv("VAULT_CACERT"),
+		ClientCert:    os.Getenv("VAULT_CLIENT_CERT"),
+		ClientKey:     os.Getenv("VAULT_CLIENT_KEY"),
+		TLSServerName: os.Getenv("VAULT_TLS_SERVER_NAME"),
+		Auth:          os.Getenv("VAULT_AUTH"),
+		AuthMount:     os.Getenv("VAULT_AUTH_MOUNT"),
+		Token:         os.Getenv("VAULT_TOKEN"),
+		RoleID:        os.Getenv("VAULT_ROLE_ID"),
+		SecretID:      os.Getenv("VAULT_SECRET_ID"),
+		SecretIDFile:  os.Getenv("VAULT_SECRET_ID_FILE"),
+		Role:          os.Getenv("VAULT_ROLE"),
+		JWTFile:       os.Getenv("VAULT_JWT_FILE"),
+		Engine:        os.Getenv("VAULT_ENGINE"),
+		Path:          os.Getenv("VAULT_PATH"),
+		CacheTTL:      5 * time.Minute,
+	}
+	logger.RegisterSecret(config.Token)
+	logger.RegisterSecret(config.SecretID)
+
+	if config.Addr == "" && os.Getenv("VAULT_HOST") != "" {
+		config.Addr = fmt.Sprintf("http://%s:%s", os.Getenv("VAULT_HOST"), os.Getenv("VAULT_PORT"))
+	}
+	if config.Auth == "" {
+		config.Auth = AuthToken
+	}
+	if config.AuthMount == "" {
+		config.AuthMount = config.Auth
+	}
+	if config.JWTFile == "" {
+		config.JWTFile = DefaultJWTFile
+	}
+	if raw := os.Getenv("VAULT_CACHE_TTL_SECONDS"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			return config, fmt.Errorf("VAULT_CACHE_TTL_SECONDS: expected a whole number of seconds, got %q", raw)
+		}
+		config.CacheTTL = time.Duration(seconds) * time.Second
+	}
+	return config, nil
+}
+
+// Enabled reports whether Vault is configured as a source of configuration
+// values.
+func (c Config) Enabled() bool {
+	return c.Addr != ""
+}
+
+// Validate reports every setting the configured auth method is missing.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Engine == "" {
+		errs = append(errs, errors.New("VAULT_ENGINE is required"))
+	}
+	if c.Path == "" {
+		errs = append(errs, errors.New("VAULT_PATH is required"))
+	}
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		errs = append(errs, errors.New("VAULT_CLIENT_CERT and VAULT_CLIENT_KEY must be set together"))
+	}
+
+	switch c.Auth {
+	case AuthToken:
+		if c.Token == "" {
+			errs = append(errs, errors.New("VAULT_TOKEN is required when VAULT_AUTH is token"))
+		}
+	case AuthAppRole:
+		if c.RoleID == "" {
+			errs = append(errs, errors.New("VAULT_ROLE_ID is required when VAULT_AUTH is approle"))
+		}
+		if c.SecretID == "" && c.SecretIDFile == "" {
+			errs = append(errs, errors.New("VAULT_SECRET_ID or VAULT_SECRET_ID_FILE is required when VAULT_AUTH is approle"))
+		}
+	case AuthKubernetes:
+		if c.Role == "" {
+			errs = append(errs, errors.New("VAULT_ROLE is required when VAULT_AUTH is kubernetes"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("VAULT_AUTH must be one of %s, %s or %s, got %q", AuthToken, AuthAppRole, AuthKubernetes, c.Auth))
+	}
+	return errors.Join(errs...)
+}
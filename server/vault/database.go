@@ -0,0 +1,332 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/daffaromero/matesite/server/helper/logger"
+	"github.com/daffaromero/matesite/server/metrics"
+	vault "github.com/hashicorp/vault/api"
+	"go.uber.org/zap"
+)
+
+// Credentials is a database username and password leased from the database
+// secrets engine.
+type Credentials struct {
+	Username string
+	Password string
+	LeaseID  string
+	// Expiry is when the lease runs out unless it is renewed.
+	Expiry time.Time
+
+	ttl       time.Duration
+	renewable bool
+	// token is the orphan token the lease was taken with, which revoking
+	// revokes the lease too.
+	token string
+}
+
+// DatabaseCredentials holds the credentials leased for a role of the database
+// secrets engine, and keeps them valid while Run is running.
+type DatabaseCredentials struct {
+	client  *Client
+	path    string
+	current atomic.Pointer[Credentials]
+
+	// mu guards subscribers, open and retired.
+	mu          sync.Mutex
+	subscribers []func(Credentials)
+	// open counts the connections open with each username.
+	open map[string]int
+	// retired holds the leases replaced by a rotation whose connections are
+	// still open, by username.
+	retired map[string]*retiredLease
+	// revoking tracks the revocations of retired leases in progress.
+	revoking sync.WaitGroup
+}
+
+// retiredLease is a lease that is revoked once its last connection is closed,
+// or when it expires.
+type retiredLease struct {
+	credentials *Credentials
+	timer       *time.Timer
+	log         *zap.Logger
+}
+
+// DatabaseCredentials leases credentials for role from the database secrets
+// engine mounted at mount.
+func (c *Client) DatabaseCredentials(ctx context.Context, mount string, role string) (*DatabaseCredentials, error) {
+	d := &DatabaseCredentials{
+		client:  c,
+		path:    mount + "/creds/" + role,
+		open:    make(map[string]int),
+		retired: make(map[string]*retiredLease),
+	}
+
+	credentials, err := d.lease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.current.Store(credentials)
+	metrics.DBCredentialExpiry.Set(float64(credentials.Expiry.Unix()))
+	return d, nil
+}
+
+// Current returns the credentials new connections should use.
+func (d *DatabaseCredentials) Current() Credentials {
+	return *d.current.Load()
+}
+
+// Subscribe calls fn with the new credentials after every rotation.
+func (d *DatabaseCredentials) Subscribe(fn func(Credentials)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, fn)
+}
+
+// ConnectionOpened records a connection opened with username. It fails when
+// the lease of username was revoked while the connection was being made.
+func (d *DatabaseCredentials) ConnectionOpened(username string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if username != d.current.Load().Username && d.retired[username] == nil {
+		return fmt.Errorf("database credentials of %s were revoked", username)
+	}
+	d.open[username]++
+	return nil
+}
+
+// ConnectionClosed records a connection opened with username being closed.
+// When it was the last one of a retired lease, the lease is revoked.
+func (d *DatabaseCredentials) ConnectionClosed(username string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.open[username]--
+	if d.open[username] > 0 {
+		return
+	}
+	delete(d.open, username)
+	if lease := d.retired[username]; lease != nil {
+		delete(d.retired, username)
+		lease.timer.Stop()
+		d.revokeRetired(lease)
+	}
+}
+
+// Run keeps the credentials valid until ctx is done. The lease is renewed
+// once two thirds of it have passed. When it cannot be renewed, or only for
+// less than half as long, new credentials are leased and the subscribers
+// notified. The old lease is revoked once the last connection opened with it
+// is closed, or when it expires if that comes first. Each lease is taken
+// with an orphan token of its own, so that logging in to Vault again does not
+// revoke it with the previous token; a lease cut short by its token expiring
+// is replaced like one that reached its max TTL. It logs through the logger
+// carried by ctx.
+func (d *DatabaseCredentials) Run(ctx context.Context) {
+	for {
+		credentials := d.current.Load()
+		if credentials.ttl <= 0 {
+			// The credentials do not expire.
+			return
+		}
+		if !sleep(ctx, time.Until(credentials.Expiry)-credentials.ttl/3) {
+			return
+		}
+
+		if credentials.renewable && d.renew(ctx, credentials) {
+			continue
+		}
+		for !d.rotate(ctx, credentials) {
+			if !sleep(ctx, retryDelay) {
+				return
+			}
+		}
+	}
+}
+
+// renew extends the lease of credentials, and reports whether they can be
+// kept.
+func (d *DatabaseCredentials) renew(ctx context.Context, credentials *Credentials) bool {
+	var secret *vault.Secret
+	api, err := d.client.as(credentials.token)
+	if err == nil {
+		secret, err = api.Sys().RenewWithContext(ctx, credentials.LeaseID, 0)
+	}
+	if err != nil {
+		metrics.DBCredentialRenewals.WithLabelValues("failure").Inc()
+		logger.FromContext(ctx).Warn("Failed to renew database credentials lease", zap.String("username", credentials.Username), zap.Error(err))
+		return false
+	}
+	metrics.DBCredentialRenewals.WithLabelValues("success").Inc()
+
+	ttl := time.Duration(secret.LeaseDuration) * time.Second
+	renewed := *credentials
+	renewed.ttl, renewed.Expiry = ttl, time.Now().Add(ttl)
+	d.current.Store(&renewed)
+	metrics.DBCredentialExpiry.Set(float64(renewed.Expiry.Unix()))
+
+	if ttl < credentials.ttl/2 {
+		logger.FromContext(ctx).Info("Database credentials lease reached its max TTL", zap.String("username", credentials.Username), zap.Time("expires_at", renewed.Expiry))
+		return false
+	}
+	logger.FromContext(ctx).Debug("Renewed database credentials lease", zap.String("username", credentials.Username), zap.Time("expires_at", renewed.Expiry))
+	return true
+}
+
+// rotate replaces previous by new credentials, and reports whether it
+// succeeded.
+func (d *DatabaseCredentials) rotate(ctx context.Context, previous *Credentials) bool {
+	credentials, err := d.lease(ctx)
+	if err != nil {
+		metrics.DBCredentialRotations.WithLabelValues("failure").Inc()
+		logger.FromContext(ctx).Error("Failed to rotate database credentials", zap.Time("expires_at", previous.Expiry), zap.Error(err))
+		return false
+	}
+
+	d.current.Store(credentials)
+	metrics.DBCredentialRotations.WithLabelValues("success").Inc()
+	metrics.DBCredentialExpiry.Set(float64(credentials.Expiry.Unix()))
+	logger.FromContext(ctx).Info("Rotated database credentials",
+		zap.String("username", credentials.Username),
+		zap.String("previous_username", previous.Username),
+		zap.Time("expires_at", credentials.Expiry),
+	)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, subscriber := range d.subscribers {
+		subscriber(*credentials)
+	}
+
+	// The subscribers have stopped handing out the previous credentials, but
+	// connections opened with them may still be in use.
+	lease := &retiredLease{credentials: previous, log: logger.FromContext(ctx)}
+	if d.open[previous.Username] == 0 {
+		d.revokeRetired(lease)
+		return true
+	}
+	d.retired[previous.Username] = lease
+	lease.timer = time.AfterFunc(time.Until(previous.Expiry), func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.retired[previous.Username] == lease {
+			delete(d.retired, previous.Username)
+			d.revokeRetired(lease)
+		}
+	})
+	return true
+}
+
+// revokeRetired revokes the lease of retired credentials in the background.
+// It is called with mu held.
+func (d *DatabaseCredentials) revokeRetired(lease *retiredLease) {
+	d.revoking.Add(1)
+	go func() {
+		defer d.revoking.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		defer cancel()
+		if err := d.revoke(ctx, lease.credentials); err != nil {
+			lease.log.Warn("Failed to revoke previous database credentials, they are left to expire",
+				zap.String("username", lease.credentials.Username),
+				zap.Time("expires_at", lease.credentials.Expiry),
+				zap.Error(err),
+			)
+			return
+		}
+		lease.log.Debug("Revoked previous database credentials", zap.String("username", lease.credentials.Username))
+	}()
+}
+
+// Revoke revokes the leases of the current credentials and of those still
+// retiring, which the database users are dropped with. It is called on
+// shutdown, once the pool using them is closed, and waits for revocations
+// already in progress.
+func (d *DatabaseCredentials) Revoke(ctx context.Context) error {
+	d.mu.Lock()
+	leases := []*Credentials{d.current.Load()}
+	for username, lease := range d.retired {
+		lease.timer.Stop()
+		leases = append(leases, lease.credentials)
+		delete(d.retired, username)
+	}
+	d.mu.Unlock()
+
+	var errs []error
+	for _, credentials := range leases {
+		errs = append(errs, d.revoke(ctx, credentials))
+	}
+	d.revoking.Wait()
+	return errors.Join(errs...)
+}
+
+// revoke revokes the orphan token of credentials, and the lease with it.
+func (d *DatabaseCredentials) revoke(ctx context.Context, credentials *Credentials) error {
+	api, err := d.client.as(credentials.token)
+	if err != nil {
+		return err
+	}
+	if err := api.Auth().Token().RevokeSelfWithContext(ctx, ""); err != nil {
+		return fmt.Errorf("failed to revoke database credentials lease %s: %w", credentials.LeaseID, err)
+	}
+	logger.UnregisterSecret(credentials.Password)
+	logger.UnregisterSecret(credentials.token)
+	return nil
+}
+
+// lease reads new credentials with a new orphan token. Creating orphan tokens
+// requires the sudo capability on auth/token/create-orphan.
+func (d *DatabaseCredentials) lease(ctx context.Context) (credentials *Credentials, err error) {
+	orphan, err := d.client.api.Auth().Token().CreateOrphanWithContext(ctx, &vault.TokenCreateRequest{
+		DisplayName: "database-credentials",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a Vault token to lease database credentials with: %w", err)
+	}
+	if orphan == nil || orphan.Auth == nil || orphan.Auth.ClientToken == "" {
+		return nil, errors.New("failed to create a Vault token to lease database credentials with: no token returned")
+	}
+	token := orphan.Auth.ClientToken
+	logger.RegisterSecret(token)
+
+	api, err := d.client.as(token)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			// Nothing was leased with the token, which is not kept.
+			api.Auth().Token().RevokeSelfWithContext(ctx, "")
+			logger.UnregisterSecret(token)
+		}
+	}()
+
+	secret, err := api.Logical().ReadWithContext(ctx, d.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database credentials from Vault at %s: %w", d.path, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("failed to read database credentials from Vault at %s: no such role", d.path)
+	}
+
+	username, _ := secret.Data["username"].(string)
+	password, _ := secret.Data["password"].(string)
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("database credentials from Vault at %s have no username or password", d.path)
+	}
+	logger.RegisterSecret(password)
+
+	ttl := time.Duration(secret.LeaseDuration) * time.Second
+	return &Credentials{
+		Username:  username,
+		Password:  password,
+		LeaseID:   secret.LeaseID,
+		Expiry:    time.Now().Add(ttl),
+		ttl:       ttl,
+		renewable: secret.Renewable,
+		token:     token,
+	}, nil
+}
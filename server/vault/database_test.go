@@ -0,0 +1,186 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// fakeDatabaseVault leases credentials app-N, each with orphan token N, and
+// records the tokens revoked.
+type fakeDatabaseVault struct {
+	mu      sync.Mutex
+	tokens  int
+	revoked []string
+}
+
+func (v *fakeDatabaseVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	token := r.Header.Get("X-Vault-Token")
+	switch r.URL.Path {
+	case "/v1/auth/token/create-orphan":
+		v.tokens++
+		json.NewEncoder(w).Encode(map[string]any{
+			"auth": map[string]any{"client_token": fmt.Sprintf("token-%d", v.tokens)},
+		})
+	case "/v1/database/creds/app":
+		var n int
+		fmt.Sscanf(token, "token-%d", &n)
+		json.NewEncoder(w).Encode(map[string]any{
+			"lease_id":       fmt.Sprintf("database/creds/app/%d", n),
+			"lease_duration": 3600,
+			"data": map[string]any{
+				"username": fmt.Sprintf("app-%d", n),
+				"password": fmt.Sprintf("password-of-app-%d", n),
+			},
+		})
+	case "/v1/auth/token/revoke-self":
+		v.revoked = append(v.revoked, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (v *fakeDatabaseVault) isRevoked(token string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Contains(v.revoked, token)
+}
+
+func newTestDatabaseCredentials(t *testing.T) (*DatabaseCredentials, *fakeDatabaseVault) {
+	t.Helper()
+	fake := &fakeDatabaseVault{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	apiConfig := vault.DefaultConfig()
+	apiConfig.Address = server.URL
+	api, err := vault.NewClient(apiConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.SetToken("root")
+	client := &Client{api: api, config: Config{Addr: server.URL}}
+
+	d, err := client.DatabaseCredentials(context.Background(), "database", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, fake
+}
+
+func TestRotateKeepsLeaseWithOpenConnections(t *testing.T) {
+	d, fake := newTestDatabaseCredentials(t)
+	ctx := context.Background()
+
+	first := d.current.Load()
+	if err := d.ConnectionOpened(first.Username); err != nil {
+		t.Fatal(err)
+	}
+	if !d.rotate(ctx, first) {
+		t.Fatal("expected the rotation to succeed")
+	}
+	d.revoking.Wait()
+	if fake.isRevoked(first.token) {
+		t.Fatal("expected the lease of a connection still open to be kept")
+	}
+	if err := d.ConnectionOpened(first.Username); err != nil {
+		t.Fatalf("expected connections to be made with a retired lease, got %v", err)
+	}
+
+	d.ConnectionClosed(first.Username)
+	d.revoking.Wait()
+	if fake.isRevoked(first.token) {
+		t.Fatal("expected the lease to be kept while a connection is open")
+	}
+
+	d.ConnectionClosed(first.Username)
+	d.revoking.Wait()
+	if !fake.isRevoked(first.token) {
+		t.Fatal("expected the lease to be revoked with its last connection closed")
+	}
+	if current := d.current.Load(); fake.isRevoked(current.token) {
+		t.Fatal("expected the current lease to be kept")
+	}
+}
+
+func TestRotateRevokesLeaseWithoutConnections(t *testing.T) {
+	d, fake := newTestDatabaseCredentials(t)
+	ctx := context.Background()
+
+	first := d.current.Load()
+	if !d.rotate(ctx, first) {
+		t.Fatal("expected the rotation to succeed")
+	}
+	d.revoking.Wait()
+	if !fake.isRevoked(first.token) {
+		t.Fatal("expected a lease without connections to be revoked at once")
+	}
+	if err := d.ConnectionOpened(first.Username); err == nil {
+		t.Fatal("expected a connection made with a revoked lease to fail")
+	}
+}
+
+func TestRotateRevokesLeaseAtExpiry(t *testing.T) {
+	d, fake := newTestDatabaseCredentials(t)
+	ctx := context.Background()
+
+	first := *d.current.Load()
+	first.Expiry = time.Now().Add(50 * time.Millisecond)
+	if err := d.ConnectionOpened(first.Username); err != nil {
+		t.Fatal(err)
+	}
+	if !d.rotate(ctx, &first) {
+		t.Fatal("expected the rotation to succeed")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !fake.isRevoked(first.token) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the lease to be revoked when it expired")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The connection closing later does not revoke it again.
+	d.ConnectionClosed(first.Username)
+	d.revoking.Wait()
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.revoked) != 1 {
+		t.Fatalf("expected one revocation, got %v", fake.revoked)
+	}
+}
+
+func TestRevokeRevokesRetiredLeases(t *testing.T) {
+	d, fake := newTestDatabaseCredentials(t)
+	ctx := context.Background()
+
+	first := d.current.Load()
+	if err := d.ConnectionOpened(first.Username); err != nil {
+		t.Fatal(err)
+	}
+	if !d.rotate(ctx, first) {
+		t.Fatal("expected the rotation to succeed")
+	}
+
+	if err := d.Revoke(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, credentials := range []*Credentials{first, d.current.Load()} {
+		if !fake.isRevoked(credentials.token) {
+			t.Fatalf("expected the lease of %s to be revoked on shutdown", credentials.Username)
+		}
+	}
+}